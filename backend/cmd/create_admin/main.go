@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"badminton_tournament/backend/internal/api"
+	"badminton_tournament/backend/internal/db"
+)
+
+// Bootstraps the first account (or any other) from the command line:
+//
+//	go run ./cmd/create_admin -username alice -password 's3cret-pass' [-role admin]
+//
+// The password can also be passed via ADMIN_PASSWORD to keep it out of shell history.
+func main() {
+	username := flag.String("username", "", "login name of the new account")
+	password := flag.String("password", os.Getenv("ADMIN_PASSWORD"), "password (defaults to $ADMIN_PASSWORD)")
+	role := flag.String("role", "admin", "role: admin, referee or viewer")
+	flag.Parse()
+
+	if *username == "" || *password == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := db.Connect(); err != nil {
+		log.Fatalf("Failed to connect to DB: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := db.Migrate(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	user, err := api.CreateUser(ctx, db.DB, *username, *password, *role)
+	if err != nil {
+		log.Fatalf("Failed to create user: %v", err)
+	}
+
+	fmt.Printf("Created %s account '%s' (ID: %s)\n", user.Role, user.Username, user.ID)
+}
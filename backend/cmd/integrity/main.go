@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/db"
+	"badminton_tournament/backend/internal/repository/postgres"
+	"badminton_tournament/backend/internal/service"
+)
+
+// Checks the bracket for dangling next-match links, misplaced teams, invalid winners and
+// duplicate knockout stages:
+//
+//	go run ./cmd/integrity [-repair] [-json]
+//
+// Exits with status 1 while issues that were not repaired remain.
+func main() {
+	repair := flag.Bool("repair", false, "fix the issues that are safe to fix")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	ctx := context.Background()
+	if err := db.Connect(); err != nil {
+		log.Fatalf("Failed to connect to DB: %v", err)
+	}
+	if err := db.Migrate(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	var report *service.IntegrityReport
+	err := db.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		report, err = service.New(postgres.NewStore(tx)).CheckIntegrity(ctx, *repair)
+		return err
+	})
+	if err != nil {
+		log.Fatalf("Integrity check failed, nothing was changed: %v", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		printReport(report, *repair)
+	}
+
+	for _, issue := range report.Issues {
+		if !issue.Repaired {
+			os.Exit(1)
+		}
+	}
+}
+
+func printReport(r *service.IntegrityReport, repair bool) {
+	fmt.Printf("Checked %d groups, %d matches\n", r.Groups, r.Matches)
+	for _, issue := range r.Issues {
+		status := "manual"
+		switch {
+		case issue.Repaired:
+			status = "repaired"
+		case issue.Repairable:
+			status = "repairable"
+		}
+		where := issue.Group
+		if issue.Match != "" {
+			where += " " + issue.Match
+		}
+		fmt.Printf("  [%-10s] %-20s %-18s %s\n", status, issue.Kind, where, issue.Message)
+	}
+
+	if len(r.Issues) == 0 {
+		fmt.Println("No issues found")
+	} else if !repair && r.Repairable() > 0 {
+		fmt.Printf("%d of %d issues can be fixed with -repair\n", r.Repairable(), len(r.Issues))
+	}
+}
@@ -1,35 +1,78 @@
+// Command migrate manages the database schema.
+//
+//	go run ./cmd/migrate [up]   apply pending migrations
+//	go run ./cmd/migrate down   roll back the last applied group (never the baseline)
+//	go run ./cmd/migrate status list migrations and whether they are applied
 package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	"badminton_tournament/backend/internal/db"
 )
 
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [up|down|status]\n", os.Args[0])
+	}
+	flag.Parse()
+
+	cmd := "up"
+	if flag.NArg() > 0 {
+		cmd = flag.Arg(0)
+	}
+
 	if err := db.Connect(); err != nil {
 		log.Fatalf("failed to connect to db: %v", err)
 	}
 
-	if err := db.CreateSchema(context.Background()); err != nil {
-		log.Fatalf("failed to create schema: %v", err)
+	ctx := context.Background()
+	migrator := db.NewMigrator()
+	if err := migrator.Init(ctx); err != nil {
+		log.Fatalf("failed to init migrations table: %v", err)
 	}
 
-	// MANUAL MIGRATION: Ensure 'pool' columns exist
-	// Bun's CreateSchema doesn't alter existing tables, so we do it manually here for the "v2" update.
-	_, err := db.DB.Exec(`
-		ALTER TABLE teams ADD COLUMN IF NOT EXISTS pool TEXT DEFAULT '';
-		ALTER TABLE groups ADD COLUMN IF NOT EXISTS pool TEXT DEFAULT '';
-		ALTER TABLE matches ADD COLUMN IF NOT EXISTS sets_detail JSONB;
-		ALTER TABLE participants ADD COLUMN IF NOT EXISTS gender TEXT DEFAULT '';
-		ALTER TABLE teams ADD COLUMN IF NOT EXISTS category TEXT DEFAULT '';
-		ALTER TABLE groups ADD COLUMN IF NOT EXISTS category TEXT DEFAULT '';
-	`)
-	if err != nil {
-		log.Printf("Warning: failed to alter tables: %v", err)
-	}
+	switch cmd {
+	case "up":
+		if err := db.Migrate(ctx); err != nil {
+			log.Fatalf("migration failed: %v", err)
+		}
+
+	case "down":
+		group, err := db.Rollback(ctx)
+		if errors.Is(err, db.ErrBaselineRollback) {
+			log.Fatalf("refusing to roll back: %v. Restore a backup or a cmd/snapshot export instead.", err)
+		}
+		if err != nil {
+			log.Fatalf("rollback failed: %v", err)
+		}
+		if group.IsZero() {
+			fmt.Println("Nothing to roll back")
+			return
+		}
+		fmt.Printf("Rolled back %s\n", group)
 
-	fmt.Println("Schema created and updated successfully")
+	case "status":
+		ms, err := migrator.MigrationsWithStatus(ctx)
+		if err != nil {
+			log.Fatalf("failed to read migrations: %v", err)
+		}
+		for _, m := range ms {
+			state := "pending"
+			if m.IsApplied() {
+				state = fmt.Sprintf("applied (group #%d, %s)", m.GroupID, m.MigratedAt.Format("2006-01-02 15:04"))
+			}
+			fmt.Printf("%-40s %s\n", m.String(), state)
+		}
+		fmt.Printf("\n%d applied, %d pending\n", len(ms.Applied()), len(ms.Unapplied()))
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
 }
@@ -13,13 +13,23 @@ import (
 )
 
 func main() {
+	if err := api.CheckJWTSecret(); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
+
 	if err := db.Connect(); err != nil {
 		log.Fatalf("Failed to connect to DB: %v", err)
 	}
 
-	// Auto-migrate schema on startup
-	if err := db.CreateSchema(context.Background()); err != nil {
-		log.Fatalf("Failed to create schema: %v", err)
+	// Apply pending schema migrations on startup (see cmd/migrate for manual control)
+	if os.Getenv("AUTO_MIGRATE") != "false" {
+		if err := db.Migrate(context.Background()); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
+	if err := api.BootstrapAdmin(context.Background(), db.DB); err != nil {
+		log.Fatalf("Failed to bootstrap admin account: %v", err)
 	}
 	
 	// Gin Mode
@@ -40,7 +50,12 @@ func main() {
 	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
 	r.Use(cors.New(config))
 
-	handler := api.NewHandler(db.DB)
+	enforcer, err := api.NewEnforcer()
+	if err != nil {
+		log.Fatalf("Failed to load RBAC policy: %v", err)
+	}
+
+	handler := api.NewHandler(db.DB, enforcer)
 	handler.RegisterRoutes(r)
 
 	port := os.Getenv("PORT")
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/db"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+	"badminton_tournament/backend/internal/repository/memory"
+	"badminton_tournament/backend/internal/repository/postgres"
+	"badminton_tournament/backend/internal/service"
+)
+
+// Plays a whole tournament through the service layer and checks the bracket invariants:
+//
+//	go run ./cmd/simulate [-seed 42] [-category MensDoubles] [-groups 1] [-runs 1] [-v]
+//	go run ./cmd/simulate -db   # same, against DATABASE_URL inside a rolled-back transaction
+//
+// Participants are created, paired into teams, drawn into GSL groups and every match is
+// given a random valid score via Tournament.RecordResult, the code path behind UpdateMatch.
+// The exit status is 1 if any invariant is violated. The knockout stage seeds one group per
+// pool, so -groups 2 reports semi-final slots being overwritten.
+
+var errRollback = errors.New("simulation finished, rolling back")
+
+type config struct {
+	seed     int64
+	category string
+	groups   int // per pool
+	pools    []string
+	verbose  bool
+}
+
+func main() {
+	cfg := config{pools: []string{"Mesoneer", "Lab"}}
+	flag.Int64Var(&cfg.seed, "seed", time.Now().UnixNano(), "random seed; rerun with the printed seed to reproduce a failure")
+	flag.StringVar(&cfg.category, "category", "MensDoubles", "MensDoubles or MixedDoubles")
+	flag.IntVar(&cfg.groups, "groups", 1, "groups per pool")
+	runs := flag.Int("runs", 1, "number of tournaments to simulate, with consecutive seeds")
+	useDB := flag.Bool("db", false, "run against DATABASE_URL (must have no participants); changes are rolled back")
+	flag.BoolVar(&cfg.verbose, "v", false, "show the propagation log")
+	flag.Parse()
+
+	if cfg.category != "MensDoubles" && cfg.category != "MixedDoubles" {
+		log.Fatalf("Unknown category %q", cfg.category)
+	}
+	if cfg.groups < 1 {
+		log.Fatal("-groups must be at least 1")
+	}
+
+	ctx := context.Background()
+	if *useDB {
+		if err := db.Connect(); err != nil {
+			log.Fatalf("Failed to connect to DB: %v", err)
+		}
+		if err := db.Migrate(ctx); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
+	failed := 0
+	for i := 0; i < *runs; i++ {
+		run := cfg
+		run.seed = cfg.seed + int64(i)
+
+		var rep *report
+		var err error
+		if *useDB {
+			err = db.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				if rep, err = simulate(ctx, postgres.NewStore(tx), run); err != nil {
+					return err
+				}
+				return errRollback
+			})
+			if errors.Is(err, errRollback) {
+				err = nil
+			}
+		} else {
+			rep, err = simulate(ctx, memory.NewStore(), run)
+		}
+		if err != nil {
+			log.Fatalf("Simulation aborted (seed %d): %v", run.seed, err)
+		}
+
+		rep.print(os.Stdout)
+		if len(rep.violations) > 0 {
+			failed++
+		}
+	}
+
+	if *runs > 1 {
+		fmt.Printf("\n%d of %d runs passed\n", *runs-failed, *runs)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// simulate plays one tournament. Errors are returned for failures of the simulation itself;
+// broken invariants end up in the report.
+func simulate(ctx context.Context, store *repository.Store, cfg config) (*report, error) {
+	rng := rand.New(rand.NewSource(cfg.seed))
+	if !cfg.verbose {
+		log.SetOutput(io.Discard)
+		defer log.SetOutput(os.Stderr)
+	}
+
+	existing, err := store.Participants.List(ctx, repository.ParticipantFilter{})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("the database already has %d participants; point DATABASE_URL at a scratch database, e.g. sqlite://simulate.db", len(existing))
+	}
+
+	slots := &slotRecorder{MatchRepository: store.Matches, fills: make(map[slotKey]int)}
+	store = &repository.Store{
+		Tournaments:  store.Tournaments,
+		Participants: store.Participants,
+		Teams:        store.Teams,
+		Groups:       store.Groups,
+		Matches:      slots,
+	}
+	svc := service.New(store)
+	svc.Shuffle = rng.Shuffle
+
+	// 1. Participants: two per team, four teams per group
+	for _, pool := range cfg.pools {
+		players := cfg.groups * 4 * 2
+		for i := 0; i < players; i++ {
+			gender := "male"
+			if cfg.category == "MixedDoubles" && i%2 == 1 {
+				gender = "female"
+			}
+			p := &models.Participant{
+				Name:       fmt.Sprintf("Sim %s %02d", pool, i+1),
+				Pool:       pool,
+				Gender:     gender,
+				Categories: []string{cfg.category},
+				Source:     "simulate",
+			}
+			if err := store.Participants.Create(ctx, p); err != nil {
+				return nil, fmt.Errorf("create participant: %w", err)
+			}
+		}
+	}
+
+	// 2. Teams and groups
+	teams, err := svc.AutoPairTeams(ctx, cfg.category)
+	if err != nil {
+		return nil, fmt.Errorf("auto-pair: %w", err)
+	}
+	tournamentID := uuid.New()
+	for _, pool := range cfg.pools {
+		if _, err := svc.AutoGenerateGroups(ctx, service.AutoGenerateGroupsInput{
+			Pool:         pool,
+			TournamentID: tournamentID,
+			NamePrefix:   pool,
+			Category:     cfg.category,
+		}); err != nil {
+			return nil, fmt.Errorf("generate %s groups: %w", pool, err)
+		}
+	}
+
+	// 3. Play every match that has both teams, in random order, until none is left
+	played := 0
+	for round := 0; ; round++ {
+		if round > 20 {
+			return nil, fmt.Errorf("matches keep becoming playable after %d rounds", round)
+		}
+		groups, err := store.Groups.List(ctx, repository.GroupFilter{TournamentID: tournamentID, Category: cfg.category})
+		if err != nil {
+			return nil, err
+		}
+
+		var playable []uuid.UUID
+		for _, g := range groups {
+			for _, m := range g.Matches {
+				if m.WinnerID == uuid.Nil && m.TeamAID != uuid.Nil && m.TeamBID != uuid.Nil {
+					playable = append(playable, m.ID)
+				}
+			}
+		}
+		if len(playable) == 0 {
+			break
+		}
+		rng.Shuffle(len(playable), func(i, j int) { playable[i], playable[j] = playable[j], playable[i] })
+
+		for _, id := range playable {
+			m, err := store.Matches.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if _, _, err := svc.RecordResult(ctx, m.ID, randomResult(rng, m)); err != nil {
+				return nil, fmt.Errorf("record result of %s: %w", m.Label, err)
+			}
+			played++
+		}
+	}
+
+	groups, err := store.Groups.List(ctx, repository.GroupFilter{TournamentID: tournamentID, Category: cfg.category})
+	if err != nil {
+		return nil, err
+	}
+	rep := &report{cfg: cfg, teams: teams, groups: groups, played: played}
+	rep.check(slots.fills)
+	return rep, nil
+}
+
+// randomResult picks a winner and a valid badminton score for the match's format.
+func randomResult(rng *rand.Rand, m *models.Match) service.Result {
+	winA := rng.Intn(2) == 0
+	need := service.BestOf(m.Label)/2 + 1
+
+	// The winner takes the last game; the loser's games, if any, come before it
+	games := make([]bool, 0, 2*need-1) // true when A wins the game
+	lost := rng.Intn(need)
+	for i := 0; i < need-1+lost; i++ {
+		games = append(games, winA)
+	}
+	for i := 0; i < lost; i++ {
+		games[i] = !winA
+	}
+	rng.Shuffle(len(games), func(i, j int) { games[i], games[j] = games[j], games[i] })
+	games = append(games, winA)
+
+	var detail string
+	setsA, setsB := 0, 0
+	for i, aWins := range games {
+		w, l := randomGame(rng)
+		a, b := w, l
+		if !aWins {
+			a, b = l, w
+			setsB++
+		} else {
+			setsA++
+		}
+		if i > 0 {
+			detail += ", "
+		}
+		detail += fmt.Sprintf("%d-%d", a, b)
+	}
+
+	winner := m.TeamBID
+	if winA {
+		winner = m.TeamAID
+	}
+	return service.Result{
+		WinnerID:   winner,
+		Score:      fmt.Sprintf("%d-%d", setsA, setsB),
+		SetsDetail: detail,
+	}
+}
+
+// randomGame returns the winner's and loser's points of one game: mostly a plain win to 21,
+// sometimes a deuce ending two points clear, rarely the 30-29 cap.
+func randomGame(rng *rand.Rand) (w, l int) {
+	switch r := rng.Intn(10); {
+	case r < 7:
+		return service.GamePoints, rng.Intn(service.GamePoints - 1)
+	case r < 9:
+		l = service.GamePoints - 1 + rng.Intn(service.GameCapPoints-service.GamePoints-1)
+		return l + 2, l
+	default:
+		return service.GameCapPoints, service.GameCapPoints - 1
+	}
+}
+
+type slotKey struct {
+	match uuid.UUID
+	col   string
+}
+
+// slotRecorder counts how often each team slot is written, so the report can tell a slot
+// that was filled twice (a promotion overwriting another) from one filled once.
+type slotRecorder struct {
+	repository.MatchRepository
+	fills map[slotKey]int
+}
+
+func (r *slotRecorder) Update(ctx context.Context, m *models.Match, columns ...string) error {
+	for _, col := range columns {
+		if col == "team_a_id" || col == "team_b_id" {
+			r.fills[slotKey{m.ID, col}]++
+		}
+	}
+	return r.MatchRepository.Update(ctx, m, columns...)
+}
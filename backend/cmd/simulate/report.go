@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+var gslLabels = []string{"M1", "M2", "Winners", "Losers", "Decider"}
+var knockoutLabels = []string{"SF1", "SF2", "Final", "Bronze"}
+
+type report struct {
+	cfg        config
+	teams      []models.Team
+	groups     []models.Group
+	played     int
+	champion   uuid.UUID
+	violations []string
+}
+
+func (r *report) fail(format string, args ...interface{}) {
+	r.violations = append(r.violations, fmt.Sprintf(format, args...))
+}
+
+func (r *report) teamName(id uuid.UUID) string {
+	for _, t := range r.teams {
+		if t.ID == id {
+			return t.Name
+		}
+	}
+	return id.String()
+}
+
+func matchByLabel(g *models.Group, label string) *models.Match {
+	for _, m := range g.Matches {
+		if m.Label == label {
+			return m
+		}
+	}
+	return nil
+}
+
+// check asserts the bracket invariants on the finished tournament.
+func (r *report) check(fills map[slotKey]int) {
+	var gsl, knockout []*models.Group
+	for i := range r.groups {
+		g := &r.groups[i]
+		if strings.HasPrefix(g.Name, "KNOCKOUT") {
+			knockout = append(knockout, g)
+		} else {
+			gsl = append(gsl, g)
+		}
+	}
+
+	// Every match is decided by one of its own two teams, with a valid score
+	for _, g := range r.groups {
+		for _, m := range g.Matches {
+			switch {
+			case m.TeamAID == uuid.Nil || m.TeamBID == uuid.Nil:
+				r.fail("%s %s never got both teams", g.Name, m.Label)
+			case m.WinnerID == uuid.Nil:
+				r.fail("%s %s was never played", g.Name, m.Label)
+			case m.WinnerID != m.TeamAID && m.WinnerID != m.TeamBID:
+				r.fail("%s %s winner %s is not one of its teams", g.Name, m.Label, m.WinnerID)
+			}
+			r.checkScore(g, m)
+		}
+	}
+
+	// GSL groups: five matches, each team plays two or three of them, and no team is in two groups
+	if want := r.cfg.groups * len(r.cfg.pools); len(gsl) != want {
+		r.fail("expected %d groups, found %d", want, len(gsl))
+	}
+	groupOf := make(map[uuid.UUID]string)
+	for _, g := range gsl {
+		for _, label := range gslLabels {
+			if matchByLabel(g, label) == nil {
+				r.fail("%s has no %s match", g.Name, label)
+			}
+		}
+		if len(g.Matches) != len(gslLabels) {
+			r.fail("%s has %d matches, expected %d", g.Name, len(g.Matches), len(gslLabels))
+		}
+
+		played := make(map[uuid.UUID]int)
+		for _, m := range g.Matches {
+			for _, id := range []uuid.UUID{m.TeamAID, m.TeamBID} {
+				if id != uuid.Nil {
+					played[id]++
+				}
+			}
+		}
+		if len(played) != 4 {
+			r.fail("%s has %d teams, expected 4", g.Name, len(played))
+		}
+		var counts []int
+		for id, n := range played {
+			counts = append(counts, n)
+			if other, ok := groupOf[id]; ok {
+				r.fail("team %s plays in both %s and %s", r.teamName(id), other, g.Name)
+			}
+			groupOf[id] = g.Name
+		}
+		sort.Ints(counts)
+		if fmt.Sprint(counts) != "[2 2 3 3]" {
+			r.fail("%s match counts per team are %v, expected [2 2 3 3]", g.Name, counts)
+		}
+	}
+	for _, t := range r.teams {
+		if _, ok := groupOf[t.ID]; !ok {
+			r.fail("team %s was not drawn into any group", t.Name)
+		}
+	}
+
+	// Knockout: one stage, every slot filled exactly once, seeded by the cross-over rule
+	if len(knockout) != 1 {
+		r.fail("expected one knockout stage, found %d", len(knockout))
+		return
+	}
+	ko := knockout[0]
+	for _, label := range knockoutLabels {
+		m := matchByLabel(ko, label)
+		if m == nil {
+			r.fail("knockout stage has no %s match", label)
+			return
+		}
+		for _, col := range []string{"team_a_id", "team_b_id"} {
+			if n := fills[slotKey{m.ID, col}]; n != 1 {
+				r.fail("knockout %s %s was filled %d times", label, col, n)
+			}
+		}
+	}
+	if r.cfg.groups == 1 {
+		r.checkCrossOver(gsl, ko)
+	}
+
+	// Exactly one champion: the Final winner, unbeaten in the knockout stage
+	final := matchByLabel(ko, "Final")
+	r.champion = final.WinnerID
+	if r.champion == uuid.Nil {
+		r.fail("no champion: the Final has no winner")
+		return
+	}
+	for _, label := range []string{"SF1", "SF2"} {
+		m := matchByLabel(ko, label)
+		if m.WinnerID != r.champion && (m.TeamAID == r.champion || m.TeamBID == r.champion) {
+			r.fail("champion %s lost %s", r.teamName(r.champion), label)
+		}
+	}
+	bronze := matchByLabel(ko, "Bronze")
+	podium := map[uuid.UUID]bool{final.TeamAID: true, final.TeamBID: true, bronze.TeamAID: true, bronze.TeamBID: true}
+	if len(podium) != 4 || podium[uuid.Nil] {
+		r.fail("the Final and Bronze match do not have four distinct teams")
+	}
+}
+
+// checkCrossOver verifies the semi-final seeding with one group per pool:
+// SF1 is Mesoneer #1 vs Lab #2, SF2 is Lab #1 vs Mesoneer #2.
+func (r *report) checkCrossOver(gsl []*models.Group, ko *models.Group) {
+	rank := make(map[string][2]uuid.UUID)
+	for _, g := range gsl {
+		var first, second uuid.UUID
+		if m := matchByLabel(g, "Winners"); m != nil {
+			first = m.WinnerID
+		}
+		if m := matchByLabel(g, "Decider"); m != nil {
+			second = m.WinnerID
+		}
+		rank[g.Pool] = [2]uuid.UUID{first, second}
+	}
+
+	want := map[string][2]uuid.UUID{
+		"SF1": {rank["Mesoneer"][0], rank["Lab"][1]},
+		"SF2": {rank["Lab"][0], rank["Mesoneer"][1]},
+	}
+	for label, teams := range want {
+		m := matchByLabel(ko, label)
+		if m.TeamAID != teams[0] || m.TeamBID != teams[1] {
+			r.fail("%s is %s vs %s, expected %s vs %s", label,
+				r.teamName(m.TeamAID), r.teamName(m.TeamBID), r.teamName(teams[0]), r.teamName(teams[1]))
+		}
+	}
+}
+
+// checkScore verifies that the stored score is a finished best-of-N badminton result won by
+// the recorded winner.
+func (r *report) checkScore(g models.Group, m *models.Match) {
+	if m.WinnerID == uuid.Nil {
+		return
+	}
+	need := service.BestOf(m.Label)/2 + 1
+	setsA, setsB := 0, 0
+	for _, game := range strings.Split(m.SetsDetail, ", ") {
+		var a, b int
+		if _, err := fmt.Sscanf(game, "%d-%d", &a, &b); err != nil {
+			r.fail("%s %s has unreadable score %q", g.Name, m.Label, m.SetsDetail)
+			return
+		}
+		if setsA == need || setsB == need {
+			r.fail("%s %s has games after the match was decided: %q", g.Name, m.Label, m.SetsDetail)
+			return
+		}
+		switch service.GameWinner(a, b) {
+		case "A":
+			setsA++
+		case "B":
+			setsB++
+		default:
+			r.fail("%s %s has an unfinished game %q", g.Name, m.Label, game)
+			return
+		}
+	}
+	if (setsA == need) != (m.WinnerID == m.TeamAID) || (setsB == need) != (m.WinnerID == m.TeamBID) {
+		r.fail("%s %s score %q does not match the winner", g.Name, m.Label, m.SetsDetail)
+	}
+	if m.Score != fmt.Sprintf("%d-%d", setsA, setsB) {
+		r.fail("%s %s score %q does not match its games %q", g.Name, m.Label, m.Score, m.SetsDetail)
+	}
+}
+
+func (r *report) print(w io.Writer) {
+	status := "PASS"
+	if len(r.violations) > 0 {
+		status = "FAIL"
+	}
+	fmt.Fprintf(w, "%s seed=%d category=%s groups=%d teams=%d matches=%d",
+		status, r.cfg.seed, r.cfg.category, r.cfg.groups*len(r.cfg.pools), len(r.teams), r.played)
+	if r.champion != uuid.Nil {
+		fmt.Fprintf(w, " champion=%q", r.teamName(r.champion))
+	}
+	fmt.Fprintln(w)
+	for _, v := range r.violations {
+		fmt.Fprintf(w, "  - %s\n", v)
+	}
+}
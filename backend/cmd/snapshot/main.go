@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/api"
+	"badminton_tournament/backend/internal/db"
+)
+
+// Saves or restores a whole tournament as a JSON archive:
+//
+//	go run ./cmd/snapshot export [-tournament <uuid>] [-o snapshot.json]
+//	go run ./cmd/snapshot restore -i snapshot.json
+//
+// Restore needs an empty (freshly migrated) database and gives every row a new ID.
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: snapshot export|restore [flags]")
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "export":
+		fs := flag.NewFlagSet("export", flag.ExitOnError)
+		tournament := fs.String("tournament", api.DefaultTournamentID.String(), "tournament ID")
+		out := fs.String("o", "", "output file (default: stdout)")
+		fs.Parse(os.Args[2:])
+		exportSnapshot(*tournament, *out)
+
+	case "restore":
+		fs := flag.NewFlagSet("restore", flag.ExitOnError)
+		in := fs.String("i", "", "snapshot file to restore")
+		fs.Parse(os.Args[2:])
+		if *in == "" {
+			fs.Usage()
+			os.Exit(2)
+		}
+		restoreSnapshot(*in)
+
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: snapshot export|restore [flags]")
+		os.Exit(2)
+	}
+}
+
+func connect(ctx context.Context) {
+	if err := db.Connect(); err != nil {
+		log.Fatalf("Failed to connect to DB: %v", err)
+	}
+	if err := db.Migrate(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+}
+
+func exportSnapshot(tournament, out string) {
+	id, err := uuid.Parse(tournament)
+	if err != nil {
+		log.Fatalf("Invalid tournament ID: %v", err)
+	}
+
+	ctx := context.Background()
+	connect(ctx)
+
+	s, err := api.ExportSnapshot(ctx, db.DB, id)
+	if err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+
+	w := os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		log.Fatalf("Failed to write snapshot: %v", err)
+	}
+
+	log.Printf("Exported '%s': %d participants, %d teams, %d groups, %d matches",
+		s.Tournament.Name, len(s.Participants), len(s.Teams), len(s.Groups), len(s.Matches))
+}
+
+func restoreSnapshot(in string) {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", in, err)
+	}
+	s, err := api.ReadSnapshot(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	connect(ctx)
+
+	var result *api.RestoreResult
+	err = db.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		result, err = api.RestoreSnapshot(ctx, tx, s)
+		return err
+	})
+	if err != nil {
+		log.Fatalf("Restore failed, nothing was written: %v", err)
+	}
+
+	fmt.Printf("Restored tournament %s: %v\n", result.TournamentID, result.Counts)
+	if result.RulesSkipped {
+		fmt.Println("Rules were kept: the target database already has rules")
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"badminton_tournament/backend/internal/models"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// recordAudit stores one administrative mutation and drops the cached stats. Failing to write
+// the audit row must not fail the request that already succeeded, so errors are only logged.
+func (h *Handler) recordAudit(c *gin.Context, action, entityType, entityID string, before, after interface{}) {
+	h.stats.invalidate()
+
+	event := &models.AuditEvent{
+		Actor:      actorFromContext(c),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     auditJSON(before),
+		After:      auditJSON(after),
+	}
+
+	if _, err := h.DB.NewInsert().Model(event).Exec(c.Request.Context()); err != nil {
+		log.Printf("[Audit] ERROR: failed to record %s on %s %s by %s: %v", action, entityType, entityID, event.Actor, err)
+	}
+}
+
+func auditJSON(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("[Audit] WARNING: failed to marshal snapshot: %v", err)
+		return nil
+	}
+	return data
+}
+
+// ListAuditEvents returns audit events, newest first
+// GET /api/admin/audit?actor=&action=&entity_type=&entity_id=&since=&until=&limit=
+func (h *Handler) ListAuditEvents(c *gin.Context) {
+	var events []models.AuditEvent
+	query := h.DB.NewSelect().Model(&events)
+
+	if actor := c.Query("actor"); actor != "" {
+		query.Where("actor = ?", actor)
+	}
+	if action := c.Query("action"); action != "" {
+		query.Where("action = ?", action)
+	}
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query.Where("entity_type = ?", entityType)
+	}
+	if entityID := c.Query("entity_id"); entityID != "" {
+		query.Where("entity_id = ?", entityID)
+	}
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC3339 timestamp"})
+			return
+		}
+		query.Where("created_at >= ?", t)
+	}
+	if until := c.Query("until"); until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be an RFC3339 timestamp"})
+			return
+		}
+		query.Where("created_at < ?", t)
+	}
+
+	limit := defaultAuditLimit
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = n
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Scan(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -1,16 +1,40 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"badminton_tournament/backend/internal/models"
 )
 
 type LoginRequest struct {
-	Password string `json:"password"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+const tokenTTL = 24 * time.Hour
+
+// errNoJWTSecret is returned while JWT_SECRET is unset: an empty HMAC key would let anyone mint
+// tokens with any role.
+var errNoJWTSecret = errors.New("JWT_SECRET is not set")
+
+// jwtSecret returns the key tokens are signed and verified with.
+func jwtSecret() ([]byte, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return nil, errNoJWTSecret
+	}
+	return []byte(secret), nil
+}
+
+// CheckJWTSecret fails when tokens could not be issued or verified safely.
+func CheckJWTSecret() error {
+	_, err := jwtSecret()
+	return err
 }
 
 func (h *Handler) Login(c *gin.Context) {
@@ -20,34 +44,75 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	adminPassword := os.Getenv("ADMIN_PASSWORD")
-	if adminPassword == "" {
-		// Fallback for dev if not set, strict for prod
-		adminPassword = "admin" 
-	}
-
-	if req.Password != adminPassword {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
+	var user models.User
+	err := h.DB.NewSelect().Model(&user).Where("username = ?", req.Username).Scan(c.Request.Context())
+	if err != nil || !checkPassword(user.PasswordHash, req.Password) {
+		// Same response for unknown users and wrong passwords
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
 		return
 	}
 
 	// Generate JWT
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"role": "admin",
-		"exp":  time.Now().Add(24 * time.Hour).Unix(),
+		"sub":  user.Username,
+		"uid":  user.ID.String(),
+		"role": user.Role,
+		"exp":  time.Now().Add(tokenTTL).Unix(),
 	})
 
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	secret, err := jwtSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	tokenString, err := token.SignedString(secret)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": tokenString})
+	c.JSON(http.StatusOK, gin.H{"token": tokenString, "username": user.Username, "role": user.Role})
 }
 
-// AuthMiddleware checks for the Authorization header
-func AuthMiddleware() gin.HandlerFunc {
+const claimsKey = "claims"
+
+// claimsFromContext returns the JWT claims stored by AuthMiddleware, or nil.
+func claimsFromContext(c *gin.Context) jwt.MapClaims {
+	v, ok := c.Get(claimsKey)
+	if !ok {
+		return nil
+	}
+	claims, _ := v.(jwt.MapClaims)
+	return claims
+}
+
+// userIDFromContext returns the account ID embedded in the JWT, or "" for tokens without one.
+func userIDFromContext(c *gin.Context) string {
+	if claims := claimsFromContext(c); claims != nil {
+		if uid, ok := claims["uid"].(string); ok {
+			return uid
+		}
+	}
+	return ""
+}
+
+// actorFromContext identifies who is performing a request, from the JWT set by AuthMiddleware.
+func actorFromContext(c *gin.Context) string {
+	claims := claimsFromContext(c)
+	if claims == nil {
+		return "anonymous"
+	}
+	if sub, ok := claims["sub"].(string); ok && sub != "" {
+		return sub
+	}
+	if role, ok := claims["role"].(string); ok && role != "" {
+		return role
+	}
+	return "unknown"
+}
+
+// AuthMiddleware checks for the Authorization header and enforces the RBAC policy for the token's role
+func (h *Handler) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenString := c.GetHeader("Authorization")
 		if tokenString == "" {
@@ -60,15 +125,48 @@ func AuthMiddleware() gin.HandlerFunc {
 			tokenString = tokenString[7:]
 		}
 
+		// Only our own HMAC tokens: the role claim decides what the caller may do
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
+			return jwtSecret()
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 		if err != nil || !token.Valid {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			return
 		}
 
+		// Expose claims to handlers (audit attribution, role checks)
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			c.Set(claimsKey, claims)
+		}
+
+		// Account tokens follow the account: deleted users are locked out and role changes
+		// apply to tokens already issued
+		if uid := userIDFromContext(c); uid != "" {
+			var user models.User
+			if err := h.DB.NewSelect().Model(&user).Column("username", "role").Where("id = ?", uid).Scan(c.Request.Context()); err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Account no longer exists"})
+				return
+			}
+			claims := claimsFromContext(c)
+			claims["sub"], claims["role"] = user.Username, user.Role
+		}
+
+		if !h.authorize(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+
+		// Match-scoped referee tokens carry the ID of their referee_tokens row
+		if claims := claimsFromContext(c); claims != nil {
+			if rtid, ok := claims["rtid"].(string); ok {
+				if err := h.checkRefereeScope(c, rtid); err != nil {
+					c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+					return
+				}
+			}
+		}
+
 		c.Next()
 	}
 }
@@ -0,0 +1,422 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+// Bracket drawing geometry, in SVG user units (pixels at scale 1).
+const (
+	bracketBoxW      = 190
+	bracketRowH      = 22
+	bracketBoxH      = 2 * bracketRowH
+	bracketLabelH    = 14
+	bracketColGap    = 46
+	bracketRowGap    = 18
+	bracketMargin    = 20
+	bracketTitleH    = 34
+	bracketHeaderH   = 26
+	bracketStageGap  = 70
+	bracketMaxNameCh = 24
+)
+
+const (
+	colorInk     = "#1f2933"
+	colorMuted   = "#7b8794"
+	colorLine    = "#9aa5b1"
+	colorBox     = "#ffffff"
+	colorWinner  = "#d9f2e3"
+	colorBorder  = "#52606d"
+	colorCanvas  = "#f5f7fa"
+	colorHeading = "#102a43"
+)
+
+// The bracket is laid out once as a display list and then written as SVG or rasterised to PNG.
+type drawRect struct {
+	X, Y, W, H   float64
+	Fill, Stroke string
+}
+
+type drawLine struct {
+	X1, Y1, X2, Y2 float64
+	Stroke         string
+	Dashed         bool
+}
+
+type drawText struct {
+	X, Y  float64 // Y is the baseline
+	Text  string
+	Size  float64
+	Bold  bool
+	Color string
+	End   bool // right-aligned at X
+}
+
+type bracketCanvas struct {
+	W, H  float64
+	Rects []drawRect
+	Lines []drawLine
+	Texts []drawText
+}
+
+// bracketStage is one block of the drawing: a GSL group or the knockout stage.
+type bracketStage struct {
+	Group   models.Group
+	columns [][]*models.Match
+}
+
+// bracketOrder is the play order, except that the Final is drawn above the Bronze match.
+func bracketOrder(label string) int {
+	if label == "Final" {
+		return 0
+	}
+	return printMatchOrder[label]
+}
+
+// matchColumns places each match in a column by how many Next* links lead into it, so the
+// drawing follows the stored flow instead of hard-coded labels.
+func matchColumns(matches []*models.Match) [][]*models.Match {
+	byID := make(map[uuid.UUID]*models.Match, len(matches))
+	for _, m := range matches {
+		byID[m.ID] = m
+	}
+	incoming := make(map[uuid.UUID][]*models.Match)
+	for _, m := range matches {
+		for _, next := range []uuid.UUID{m.NextMatchWinID, m.NextMatchLoseID} {
+			if _, ok := byID[next]; ok {
+				incoming[next] = append(incoming[next], m)
+			}
+		}
+	}
+
+	depth := make(map[uuid.UUID]int, len(matches))
+	var depthOf func(m *models.Match, seen map[uuid.UUID]bool) int
+	depthOf = func(m *models.Match, seen map[uuid.UUID]bool) int {
+		if d, ok := depth[m.ID]; ok {
+			return d
+		}
+		if seen[m.ID] { // Guard against a corrupted cyclic link
+			return 0
+		}
+		seen[m.ID] = true
+		d := 0
+		for _, src := range incoming[m.ID] {
+			if sd := depthOf(src, seen) + 1; sd > d {
+				d = sd
+			}
+		}
+		depth[m.ID] = d
+		return d
+	}
+
+	var columns [][]*models.Match
+	for _, m := range matches {
+		d := depthOf(m, map[uuid.UUID]bool{})
+		for len(columns) <= d {
+			columns = append(columns, nil)
+		}
+		columns[d] = append(columns[d], m)
+	}
+	for _, col := range columns {
+		sort.SliceStable(col, func(i, j int) bool {
+			return bracketOrder(col[i].Label) < bracketOrder(col[j].Label)
+		})
+	}
+	return columns
+}
+
+func (s *bracketStage) size() (w, h float64) {
+	rows := 0
+	for _, col := range s.columns {
+		if len(col) > rows {
+			rows = len(col)
+		}
+	}
+	cols := len(s.columns)
+	w = float64(cols)*bracketBoxW + float64(cols-1)*bracketColGap
+	h = bracketHeaderH + float64(rows)*(bracketLabelH+bracketBoxH) + float64(rows-1)*bracketRowGap
+	return w, h
+}
+
+func truncateName(name string) string {
+	r := []rune(name)
+	if len(r) <= bracketMaxNameCh {
+		return name
+	}
+	return string(r[:bracketMaxNameCh-1]) + "…"
+}
+
+// draw adds the stage at (x, y) to the canvas.
+func (s *bracketStage) draw(cv *bracketCanvas, x, y float64, teams map[uuid.UUID]string) {
+	_, h := s.size()
+	title := s.Group.Name
+	if s.Group.Pool != "" {
+		title += " (" + s.Group.Pool + ")"
+	}
+	cv.Texts = append(cv.Texts, drawText{X: x, Y: y + 16, Text: title, Size: 14, Bold: true, Color: colorHeading})
+
+	type anchor struct{ x, y float64 }
+	in := make(map[uuid.UUID]anchor)
+	out := make(map[uuid.UUID]anchor)
+
+	bodyH := h - bracketHeaderH
+	for ci, col := range s.columns {
+		colX := x + float64(ci)*(bracketBoxW+bracketColGap)
+		colH := float64(len(col))*(bracketLabelH+bracketBoxH) + float64(len(col)-1)*bracketRowGap
+		rowY := y + bracketHeaderH + (bodyH-colH)/2
+
+		for _, m := range col {
+			boxY := rowY + bracketLabelH
+			s.drawMatch(cv, m, colX, boxY, teams)
+			in[m.ID] = anchor{colX, boxY + bracketBoxH/2}
+			out[m.ID] = anchor{colX + bracketBoxW, boxY + bracketBoxH/2}
+			rowY += bracketLabelH + bracketBoxH + bracketRowGap
+		}
+	}
+
+	// Elbow connectors: solid for the winner's path, dashed for the loser's
+	for _, col := range s.columns {
+		for _, m := range col {
+			from := out[m.ID]
+			for _, link := range []struct {
+				id     uuid.UUID
+				dashed bool
+			}{{m.NextMatchWinID, false}, {m.NextMatchLoseID, true}} {
+				to, ok := in[link.id]
+				if !ok {
+					continue
+				}
+				midX := from.x + bracketColGap/2
+				if link.dashed {
+					midX = from.x + bracketColGap/3 // Keep win and lose paths apart where they overlap
+				}
+				cv.Lines = append(cv.Lines,
+					drawLine{from.x, from.y, midX, from.y, colorLine, link.dashed},
+					drawLine{midX, from.y, midX, to.y, colorLine, link.dashed},
+					drawLine{midX, to.y, to.x, to.y, colorLine, link.dashed},
+				)
+			}
+		}
+	}
+}
+
+func (s *bracketStage) drawMatch(cv *bracketCanvas, m *models.Match, x, y float64, teams map[uuid.UUID]string) {
+	label := m.Label
+	if bo := service.BestOf(m.Label); bo > 1 {
+		label += fmt.Sprintf(" · Bo%d", bo)
+	}
+	if m.Court != "" {
+		label += " · Court " + m.Court
+	}
+	cv.Texts = append(cv.Texts, drawText{X: x + 2, Y: y - 4, Text: label, Size: 10, Color: colorMuted})
+
+	placeholders := printPlaceholders[m.Label]
+	sides := []struct {
+		id          uuid.UUID
+		placeholder string
+	}{{m.TeamAID, placeholders[0]}, {m.TeamBID, placeholders[1]}}
+
+	for i, side := range sides {
+		rowY := y + float64(i)*bracketRowH
+		won := m.WinnerID != uuid.Nil && side.id == m.WinnerID
+		fill := colorBox
+		if won {
+			fill = colorWinner
+		}
+		cv.Rects = append(cv.Rects, drawRect{x, rowY, bracketBoxW, bracketRowH, fill, colorBorder})
+
+		name, color := teams[side.id], colorInk
+		if side.id == uuid.Nil || name == "" {
+			name, color = side.placeholder, colorMuted
+		}
+		cv.Texts = append(cv.Texts, drawText{X: x + 6, Y: rowY + 15, Text: truncateName(name), Size: 11, Bold: won, Color: color})
+
+		// The score is printed on the winner's row, or the first row until there is one
+		if m.Score != "" && (won || (m.WinnerID == uuid.Nil && i == 0)) {
+			cv.Texts = append(cv.Texts, drawText{X: x + bracketBoxW - 6, Y: rowY + 15, Text: m.Score, Size: 10, Bold: true, Color: colorInk, End: true})
+		}
+	}
+}
+
+// layoutBracket stacks the GSL groups on the left and places the knockout stage to their right.
+func layoutBracket(category string, groups []models.Group, teams map[uuid.UUID]string) *bracketCanvas {
+	var gsl, knockout []*bracketStage
+	for i := range groups {
+		g := &groups[i]
+		stage := &bracketStage{Group: *g, columns: matchColumns(g.Matches)}
+		if len(stage.columns) == 0 {
+			continue
+		}
+		if isKnockoutGroup(g) {
+			knockout = append(knockout, stage)
+		} else {
+			gsl = append(gsl, stage)
+		}
+	}
+
+	stack := func(stages []*bracketStage) (w, h float64) {
+		for i, s := range stages {
+			sw, sh := s.size()
+			if sw > w {
+				w = sw
+			}
+			if i > 0 {
+				h += bracketStageGap / 2
+			}
+			h += sh
+		}
+		return w, h
+	}
+	leftW, leftH := stack(gsl)
+	rightW, rightH := stack(knockout)
+
+	cv := &bracketCanvas{}
+	bodyH := leftH
+	if rightH > bodyH {
+		bodyH = rightH
+	}
+	cv.W = bracketMargin*2 + leftW + rightW
+	if leftW > 0 && rightW > 0 {
+		cv.W += bracketStageGap
+	}
+	if cv.W < 320 {
+		cv.W = 320
+	}
+	cv.H = bracketMargin*2 + bracketTitleH + bodyH
+
+	title := "Bracket"
+	if category != "" {
+		title += " · " + category
+	}
+	cv.Rects = append(cv.Rects, drawRect{0, 0, cv.W, cv.H, colorCanvas, ""})
+	cv.Texts = append(cv.Texts, drawText{X: bracketMargin, Y: bracketMargin + 18, Text: title, Size: 18, Bold: true, Color: colorHeading})
+
+	place := func(stages []*bracketStage, x, totalH float64) {
+		y := bracketMargin + bracketTitleH + (bodyH-totalH)/2
+		for _, s := range stages {
+			s.draw(cv, x, y, teams)
+			_, sh := s.size()
+			y += sh + bracketStageGap/2
+		}
+	}
+	place(gsl, bracketMargin, leftH)
+	rightX := float64(bracketMargin) + leftW
+	if leftW > 0 {
+		rightX += bracketStageGap
+	}
+	place(knockout, rightX, rightH)
+
+	return cv
+}
+
+func svgAttr(name, value string) string {
+	if value == "" {
+		return ""
+	}
+	return fmt.Sprintf(` %s="%s"`, name, html.EscapeString(value))
+}
+
+// writeSVG serialises the canvas as a standalone SVG document.
+func writeSVG(w io.Writer, cv *bracketCanvas) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Go, 'Helvetica Neue', Arial, sans-serif">`+"\n",
+		cv.W, cv.H, cv.W, cv.H)
+	for _, r := range cv.Rects {
+		fill := r.Fill
+		if fill == "" {
+			fill = "none"
+		}
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"%s/>`+"\n", r.X, r.Y, r.W, r.H, fill, svgAttr("stroke", r.Stroke))
+	}
+	for _, l := range cv.Lines {
+		dash := ""
+		if l.Dashed {
+			dash = ` stroke-dasharray="4 3"`
+		}
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="1.5"%s/>`+"\n", l.X1, l.Y1, l.X2, l.Y2, l.Stroke, dash)
+	}
+	for _, t := range cv.Texts {
+		weight, anchor := "", ""
+		if t.Bold {
+			weight = ` font-weight="bold"`
+		}
+		if t.End {
+			anchor = ` text-anchor="end"`
+		}
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-size="%.0f" fill="%s"%s%s>%s</text>`+"\n", t.X, t.Y, t.Size, t.Color, weight, anchor, html.EscapeString(t.Text))
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// loadBracket lays out every group of a category, knockout included.
+func (h *Handler) loadBracket(ctx context.Context, category string) (*bracketCanvas, error) {
+	groups, err := h.loadGroupsWithMatches(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	names, err := h.teamNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return layoutBracket(category, groups, names), nil
+}
+
+// GetBracketSVG renders a category's groups and knockout tree as a standalone image
+// GET /api/bracket.svg?category=MensDoubles
+func (h *Handler) GetBracketSVG(c *gin.Context) {
+	cv, err := h.loadBracket(c.Request.Context(), c.Query("category"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "image/svg+xml")
+	c.Header("Cache-Control", "public, max-age=60")
+	c.Status(http.StatusOK)
+	if err := writeSVG(c.Writer, cv); err != nil {
+		c.Error(err)
+	}
+}
+
+// GetBracketPNG is GetBracketSVG rasterised in pure Go; ?scale=1..4 (default 2)
+// GET /api/bracket.png?category=MensDoubles
+func (h *Handler) GetBracketPNG(c *gin.Context) {
+	scale := 2.0
+	if s := c.Query("scale"); s != "" {
+		if _, err := fmt.Sscan(s, &scale); err != nil || scale < 1 || scale > 4 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scale must be between 1 and 4"})
+			return
+		}
+	}
+
+	cv, err := h.loadBracket(c.Request.Context(), c.Query("category"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	img, err := rasterizeCanvas(cv, scale)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "image/png")
+	c.Header("Cache-Control", "public, max-age=60")
+	c.Status(http.StatusOK)
+	if err := encodePNG(c.Writer, img); err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+const (
+	deliveryProcessed = "processed"
+	deliveryFailed    = "failed"
+)
+
+type UpdateDeliveryRequest struct {
+	Payload string `json:"payload" binding:"required"`
+}
+
+// processDelivery ingests the stored payload and records the outcome on the delivery row.
+func (h *Handler) processDelivery(ctx context.Context, delivery *models.WebhookDelivery) (*models.Participant, error) {
+	participant, procErr := h.ingestFormPayload(ctx, delivery.Source, []byte(delivery.Payload))
+
+	delivery.Attempts++
+	delivery.ProcessedAt = time.Now()
+	if procErr != nil {
+		delivery.Status = deliveryFailed
+		delivery.Error = procErr.Error()
+	} else {
+		delivery.Status = deliveryProcessed
+		delivery.Error = ""
+		delivery.ParticipantID = participant.ID
+	}
+
+	if _, err := h.DB.NewUpdate().Model(delivery).
+		Column("payload", "status", "error", "participant_id", "attempts", "processed_at").
+		WherePK().
+		Exec(ctx); err != nil {
+		log.Printf("[Webhook] ERROR: failed to record outcome of delivery %s: %v", delivery.ID, err)
+	}
+
+	return participant, procErr
+}
+
+// ListWebhookDeliveries returns inbound submissions, newest first
+// GET /api/admin/webhook-deliveries?status=failed&source=
+func (h *Handler) ListWebhookDeliveries(c *gin.Context) {
+	var deliveries []models.WebhookDelivery
+	query := h.DB.NewSelect().Model(&deliveries)
+
+	if status := c.Query("status"); status != "" {
+		query.Where("status = ?", status)
+	}
+	if source := c.Query("source"); source != "" {
+		query.Where("source = ?", source)
+	}
+
+	if err := query.Order("created_at DESC").Limit(maxAuditLimit).Scan(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// GetWebhookDelivery returns one submission with its raw payload
+// GET /api/admin/webhook-deliveries/:id
+func (h *Handler) GetWebhookDelivery(c *gin.Context) {
+	var delivery models.WebhookDelivery
+	if err := h.DB.NewSelect().Model(&delivery).Where("id = ?", c.Param("id")).Scan(c.Request.Context()); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// UpdateWebhookDelivery replaces the payload of a failed delivery so it can be replayed
+// PUT /api/admin/webhook-deliveries/:id
+func (h *Handler) UpdateWebhookDelivery(c *gin.Context) {
+	var req UpdateDeliveryRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var delivery models.WebhookDelivery
+	if err := h.DB.NewSelect().Model(&delivery).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	}
+	if delivery.Status == deliveryProcessed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Delivery was already processed"})
+		return
+	}
+
+	before := delivery
+	delivery.Payload = req.Payload
+	if _, err := h.DB.NewUpdate().Model(&delivery).Column("payload").WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "UpdateWebhookDelivery", "webhook_delivery", delivery.ID.String(), before, delivery)
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// ReplayWebhookDelivery processes a stored delivery again. Ingestion upserts by name,
+// so replaying an already processed delivery is harmless.
+// POST /api/admin/webhook-deliveries/:id/replay
+func (h *Handler) ReplayWebhookDelivery(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var delivery models.WebhookDelivery
+	if err := h.DB.NewSelect().Model(&delivery).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	}
+
+	participant, err := h.processDelivery(ctx, &delivery)
+	h.recordAudit(c, "ReplayWebhookDelivery", "webhook_delivery", delivery.ID.String(), nil, delivery)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var perr *service.PhaseError
+		if errors.Is(err, errInvalidPayload) {
+			status = http.StatusBadRequest
+		} else if errors.As(err, &perr) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error(), "delivery": delivery})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "id": participant.ID, "delivery": delivery})
+}
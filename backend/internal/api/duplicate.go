@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+// DuplicateGroup is a set of participants whose names only differ by accents, case or spacing.
+type DuplicateGroup struct {
+	NormalizedName string               `json:"normalized_name"`
+	Participants   []models.Participant `json:"participants"`
+}
+
+type MergeParticipantsRequest struct {
+	SurvivorID   uuid.UUID   `json:"survivor_id" binding:"required"`
+	DuplicateIDs []uuid.UUID `json:"duplicate_ids" binding:"required"`
+}
+
+// warnPossibleDuplicates logs when a freshly ingested participant looks like an existing one.
+func (h *Handler) warnPossibleDuplicates(ctx context.Context, p *models.Participant) {
+	var others []models.Participant
+	if err := h.DB.NewSelect().Model(&others).Column("id", "name").Where("id != ?", p.ID).Scan(ctx); err != nil {
+		return
+	}
+	key := models.NormalizeName(p.Name)
+	for _, o := range others {
+		if models.NormalizeName(o.Name) == key {
+			log.Printf("[Participants] WARNING: '%s' (%s) may be a duplicate of '%s' (%s)", p.Name, p.ID, o.Name, o.ID)
+		}
+	}
+}
+
+// findDuplicateGroups groups participants by their accent-insensitive name.
+func findDuplicateGroups(participants []models.Participant) []DuplicateGroup {
+	byKey := make(map[string][]models.Participant)
+	for _, p := range participants {
+		key := models.NormalizeName(p.Name)
+		byKey[key] = append(byKey[key], p)
+	}
+
+	groups := []DuplicateGroup{}
+	for key, ps := range byKey {
+		if len(ps) > 1 {
+			groups = append(groups, DuplicateGroup{NormalizedName: key, Participants: ps})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].NormalizedName < groups[j].NormalizedName })
+	return groups
+}
+
+// ListDuplicateParticipants returns groups of likely duplicate registrations
+// GET /api/admin/participants/duplicates
+func (h *Handler) ListDuplicateParticipants(c *gin.Context) {
+	var participants []models.Participant
+	if err := h.DB.NewSelect().Model(&participants).Order("created_at ASC").Scan(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, findDuplicateGroups(participants))
+}
+
+func mergeStringSets(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var out []string
+	for _, s := range append(append([]string{}, a...), b...) {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// mergeParticipants folds duplicates into the survivor inside tx: teams (and through them all
+// match history) are re-pointed, registration data is combined and the duplicates are deleted.
+func mergeParticipants(ctx context.Context, tx bun.Tx, survivorID uuid.UUID, duplicateIDs []uuid.UUID) (*models.Participant, error) {
+	var survivor models.Participant
+	if err := tx.NewSelect().Model(&survivor).Where("id = ?", survivorID).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("survivor %s not found", survivorID)
+	}
+
+	// A repeated ID names the same duplicate
+	seen := make(map[uuid.UUID]bool, len(duplicateIDs))
+	unique := make([]uuid.UUID, 0, len(duplicateIDs))
+	for _, id := range duplicateIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	duplicateIDs = unique
+
+	var duplicates []models.Participant
+	if err := tx.NewSelect().Model(&duplicates).Where("id IN (?)", bun.In(duplicateIDs)).Scan(ctx); err != nil {
+		return nil, err
+	}
+	if len(duplicates) != len(duplicateIDs) {
+		return nil, fmt.Errorf("one or more duplicates not found")
+	}
+
+	for _, d := range duplicates {
+		if d.ID == survivor.ID {
+			return nil, fmt.Errorf("survivor cannot also be a duplicate")
+		}
+
+		// A team made of the survivor and its own duplicate cannot be merged into one player
+		count, err := tx.NewSelect().Model((*models.Team)(nil)).
+			Where("(player1_id = ? AND player2_id = ?) OR (player1_id = ? AND player2_id = ?)", survivor.ID, d.ID, d.ID, survivor.ID).
+			Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("'%s' and '%s' are partners in the same team", survivor.Name, d.Name)
+		}
+
+		survivor.Categories = mergeStringSets(survivor.Categories, d.Categories)
+		survivor.AvailableDates = mergeStringSets(survivor.AvailableDates, d.AvailableDates)
+		if survivor.ExternalID == "" {
+			survivor.ExternalID = d.ExternalID
+		}
+		if survivor.Gender == "" {
+			survivor.Gender = d.Gender
+		}
+	}
+
+	// After the merge one person may only play one team per category
+	all := append([]uuid.UUID{survivor.ID}, duplicateIDs...)
+	var involved []models.Team
+	if err := tx.NewSelect().Model(&involved).
+		Where("player1_id IN (?) OR player2_id IN (?)", bun.In(all), bun.In(all)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	perCategory := make(map[string]string)
+	for _, t := range involved {
+		// The duplicates' teams move to the survivor, and a team keeps to one pool
+		if t.Pool != survivor.Pool {
+			return nil, fmt.Errorf("team '%s' plays in pool %s but '%s' is in pool %s", t.Name, t.Pool, survivor.Name, survivor.Pool)
+		}
+		if other, ok := perCategory[t.Category]; ok {
+			return nil, fmt.Errorf("merged participant would play %s in two teams ('%s' and '%s'); disband one first", t.Category, other, t.Name)
+		}
+		perCategory[t.Category] = t.Name
+	}
+
+	if _, err := tx.NewUpdate().Model((*models.Team)(nil)).
+		Set("player1_id = ?", survivor.ID).
+		Where("player1_id IN (?)", bun.In(duplicateIDs)).
+		Exec(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := tx.NewUpdate().Model((*models.Team)(nil)).
+		Set("player2_id = ?", survivor.ID).
+		Where("player2_id IN (?)", bun.In(duplicateIDs)).
+		Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	// Free the duplicates' unique external IDs before the survivor takes one over
+	if _, err := tx.NewDelete().Model((*models.Participant)(nil)).Where("id IN (?)", bun.In(duplicateIDs)).Exec(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := tx.NewUpdate().Model(&survivor).
+		Column("external_id", "categories", "available_dates", "gender").
+		WherePK().
+		Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := refreshTeamNames(ctx, tx, survivor.ID); err != nil {
+		return nil, err
+	}
+
+	return &survivor, nil
+}
+
+// MergeParticipants merges duplicate registrations into one surviving participant
+// POST /api/admin/participants/merge
+func (h *Handler) MergeParticipants(c *gin.Context) {
+	var req MergeParticipantsRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.DuplicateIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "duplicate_ids must not be empty"})
+		return
+	}
+	if !h.requirePhase(c, DefaultTournamentID, service.OpRegister) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var before []models.Participant
+	if err := h.DB.NewSelect().Model(&before).
+		Where("id IN (?)", bun.In(append([]uuid.UUID{req.SurvivorID}, req.DuplicateIDs...))).
+		Scan(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var survivor *models.Participant
+	err := h.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		survivor, err = mergeParticipants(ctx, tx, req.SurvivorID, req.DuplicateIDs)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "MergeParticipants", "participant", survivor.ID.String(), before, survivor)
+
+	c.JSON(http.StatusOK, survivor)
+}
@@ -0,0 +1,422 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/xuri/excelize/v2"
+	"badminton_tournament/backend/internal/db"
+	"badminton_tournament/backend/internal/models"
+)
+
+// exportFlushEvery controls how often buffered rows are pushed to the client.
+const exportFlushEvery = 200
+
+// exportWriter serialises one tabular dataset row by row.
+type exportWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []string) error
+	Close() error
+}
+
+type csvExportWriter struct {
+	w    *csv.Writer
+	f    http.Flusher
+	rows int
+}
+
+func (e *csvExportWriter) WriteHeader(columns []string) error {
+	return e.w.Write(columns)
+}
+
+func (e *csvExportWriter) WriteRow(values []string) error {
+	if err := e.w.Write(values); err != nil {
+		return err
+	}
+	if e.rows++; e.rows%exportFlushEvery == 0 {
+		e.w.Flush()
+		e.f.Flush()
+	}
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonExportWriter emits an array of objects keyed by column name, one element at a time.
+type jsonExportWriter struct {
+	out     io.Writer
+	f       http.Flusher
+	columns []string
+	rows    int
+}
+
+func (e *jsonExportWriter) WriteHeader(columns []string) error {
+	e.columns = columns
+	_, err := io.WriteString(e.out, "[")
+	return err
+}
+
+func (e *jsonExportWriter) WriteRow(values []string) error {
+	obj := make(map[string]string, len(e.columns))
+	for i, col := range e.columns {
+		obj[col] = values[i]
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.rows == 0 {
+		sep = "\n"
+	}
+	if _, err := io.WriteString(e.out, sep); err != nil {
+		return err
+	}
+	if _, err := e.out.Write(data); err != nil {
+		return err
+	}
+	if e.rows++; e.rows%exportFlushEvery == 0 {
+		e.f.Flush()
+	}
+	return nil
+}
+
+func (e *jsonExportWriter) Close() error {
+	_, err := io.WriteString(e.out, "\n]\n")
+	return err
+}
+
+// xlsxExportWriter uses excelize's stream writer, which spills rows to a temp file instead of
+// holding them in memory. The zip container can only be written once it is complete.
+type xlsxExportWriter struct {
+	out  io.Writer
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	row  int
+}
+
+func newXLSXExportWriter(out io.Writer, sheet string) (*xlsxExportWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", sheet); err != nil {
+		return nil, err
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxExportWriter{out: out, file: f, sw: sw}, nil
+}
+
+func (e *xlsxExportWriter) WriteHeader(columns []string) error {
+	return e.WriteRow(columns)
+}
+
+func (e *xlsxExportWriter) WriteRow(values []string) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+	return e.sw.SetRow(cell, row)
+}
+
+func (e *xlsxExportWriter) Close() error {
+	defer e.file.Close()
+	if err := e.sw.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.out)
+}
+
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportFilter narrows a dataset to a category if set. Placements are per tournament: the
+// default one unless tournament_id is given.
+type exportFilter struct {
+	category     string
+	tournamentID uuid.UUID
+}
+
+// exportDataset writes the header and every row of one dataset.
+type exportDataset struct {
+	columns []string
+	rows    func(h *Handler, ctx context.Context, filter exportFilter, emit func([]string) error) error
+}
+
+var exportDatasets = map[string]exportDataset{
+	"participants": {
+		columns: []string{"id", "name", "external_id", "pool", "gender", "categories", "available_dates", "source", "status", "created_at"},
+		rows:    (*Handler).exportParticipants,
+	},
+	"teams": {
+		columns: []string{"id", "name", "category", "pool", "player1", "player2"},
+		rows:    (*Handler).exportTeams,
+	},
+	"matches": {
+		columns: []string{"id", "category", "group", "label", "court", "team_a", "team_b", "winner", "score", "sets_detail", "video_url"},
+		rows:    (*Handler).exportMatches,
+	},
+	"rankings": {
+		columns: []string{"category", "group", "pool", "rank", "team_id", "team"},
+		rows:    (*Handler).exportRankings,
+	},
+	"placements": {
+		columns: []string{"category", "rank", "team_id", "team", "player1", "player2"},
+		rows:    (*Handler).exportPlacements,
+	},
+}
+
+func uuidString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+// streamRows runs q as a cursor and scans one row at a time into dest before calling fn.
+func streamRows(ctx context.Context, q *bun.SelectQuery, dest interface{}, fn func() error) error {
+	rows, err := q.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := q.DB().ScanRow(ctx, rows, dest); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (h *Handler) exportParticipants(ctx context.Context, filter exportFilter, emit func([]string) error) error {
+	var p models.Participant
+	q := h.DB.NewSelect().Model(&p).Order("name ASC")
+	if filter.category != "" {
+		if db.IsSQLite(h.DB) {
+			q.Where("EXISTS (SELECT 1 FROM json_each(p.categories) WHERE value = ?)", filter.category)
+		} else {
+			q.Where("? = ANY(categories)", filter.category)
+		}
+	}
+	return streamRows(ctx, q, &p, func() error {
+		row := []string{
+			p.ID.String(), p.Name, p.ExternalID, p.Pool, p.Gender,
+			strings.Join(p.Categories, ", "), strings.Join(p.AvailableDates, ", "),
+			p.Source, p.Status, p.CreatedAt.Format(time.RFC3339),
+		}
+		p = models.Participant{}
+		return emit(row)
+	})
+}
+
+func (h *Handler) exportTeams(ctx context.Context, filter exportFilter, emit func([]string) error) error {
+	var participants []models.Participant
+	if err := h.DB.NewSelect().Model(&participants).Column("id", "name").Scan(ctx); err != nil {
+		return err
+	}
+	players := make(map[uuid.UUID]string, len(participants))
+	for _, p := range participants {
+		players[p.ID] = p.Name
+	}
+
+	var t models.Team
+	q := h.DB.NewSelect().Model(&t).Order("category ASC", "name ASC")
+	if filter.category != "" {
+		q.Where("category = ?", filter.category)
+	}
+	return streamRows(ctx, q, &t, func() error {
+		row := []string{t.ID.String(), t.Name, t.Category, t.Pool, players[t.Player1ID], players[t.Player2ID]}
+		t = models.Team{}
+		return emit(row)
+	})
+}
+
+// teamNames maps team IDs to display names; teams are few enough to hold in memory.
+func (h *Handler) teamNames(ctx context.Context) (map[uuid.UUID]string, error) {
+	var teams []models.Team
+	if err := h.DB.NewSelect().Model(&teams).Column("id", "name").Scan(ctx); err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(teams))
+	for _, t := range teams {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+func (h *Handler) exportMatches(ctx context.Context, filter exportFilter, emit func([]string) error) error {
+	var groups []models.Group
+	q := h.DB.NewSelect().Model(&groups)
+	if filter.category != "" {
+		q.Where("category = ?", filter.category)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	groupByID := make(map[uuid.UUID]models.Group, len(groups))
+	groupIDs := make([]uuid.UUID, 0, len(groups))
+	for _, g := range groups {
+		groupByID[g.ID] = g
+		groupIDs = append(groupIDs, g.ID)
+	}
+
+	names, err := h.teamNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	var m models.Match
+	mq := h.DB.NewSelect().Model(&m).Where("group_id IN (?)", bun.In(groupIDs)).Order("group_id ASC", "label ASC")
+	return streamRows(ctx, mq, &m, func() error {
+		g := groupByID[m.GroupID]
+		row := []string{
+			m.ID.String(), g.Category, g.Name, m.Label, m.Court,
+			names[m.TeamAID], names[m.TeamBID], names[m.WinnerID],
+			m.Score, m.SetsDetail, m.VideoURL,
+		}
+		m = models.Match{}
+		return emit(row)
+	})
+}
+
+func (h *Handler) loadGroupsWithMatches(ctx context.Context, category string) ([]models.Group, error) {
+	var groups []models.Group
+	q := h.DB.NewSelect().Model(&groups).Relation("Matches").Order("category ASC", "name ASC")
+	if category != "" {
+		q.Where("category = ?", category)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (h *Handler) exportRankings(ctx context.Context, filter exportFilter, emit func([]string) error) error {
+	groups, err := h.loadGroupsWithMatches(ctx, filter.category)
+	if err != nil {
+		return err
+	}
+	names, err := h.teamNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range groups {
+		g := &groups[i]
+		if isKnockoutGroup(g) {
+			continue
+		}
+		for _, s := range groupStandings(g) {
+			row := []string{g.Category, g.Name, g.Pool, fmt.Sprint(s.Rank), uuidString(s.TeamID), names[s.TeamID]}
+			if err := emit(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *Handler) exportPlacements(ctx context.Context, filter exportFilter, emit func([]string) error) error {
+	categories, err := h.loadPlacements(ctx, filter.tournamentID, filter.category)
+	if err != nil {
+		return err
+	}
+
+	for _, cp := range categories {
+		for _, p := range cp.Placements {
+			row := []string{cp.Category, fmt.Sprint(p.Rank), uuidString(p.TeamID), p.TeamName, "", ""}
+			copy(row[4:], p.Players)
+			if err := emit(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Export streams a dataset as CSV, JSON or XLSX
+// GET /api/admin/export/:dataset?format=csv|json|xlsx&category=MensDoubles&tournament_id=<uuid>
+// Datasets: participants, teams, matches, rankings, placements (tournament_id applies to placements)
+func (h *Handler) Export(c *gin.Context) {
+	name := c.Param("dataset")
+	dataset, ok := exportDatasets[name]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown dataset: " + name})
+		return
+	}
+	format := c.DefaultQuery("format", "csv")
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv, json or xlsx"})
+		return
+	}
+	filter := exportFilter{category: c.Query("category"), tournamentID: DefaultTournamentID}
+	if raw := c.Query("tournament_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament_id"})
+			return
+		}
+		filter.tournamentID = id
+	}
+
+	var out exportWriter
+	switch format {
+	case "csv":
+		out = &csvExportWriter{w: csv.NewWriter(c.Writer), f: c.Writer}
+	case "json":
+		out = &jsonExportWriter{out: c.Writer, f: c.Writer}
+	case "xlsx":
+		w, err := newXLSXExportWriter(c.Writer, name)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		out = w
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Once the first bytes are out the status can no longer change; a failure truncates the file
+	err := out.WriteHeader(dataset.columns)
+	if err == nil {
+		err = dataset.rows(h, c.Request.Context(), filter, out.WriteRow)
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		log.Printf("[Export] %s as %s failed: %v", name, format, err)
+		c.Error(err)
+	}
+}
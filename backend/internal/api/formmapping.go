@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"badminton_tournament/backend/internal/models"
+)
+
+// FormSubmission is a registration after field mapping and value translation.
+type FormSubmission struct {
+	Name           string
+	ExternalID     string
+	Pool           string
+	Categories     []string
+	AvailableDates []string
+	Gender         string
+	Source         string
+	Status         string
+}
+
+// mappableFields are the participant fields a FormMapping may target. The list fields
+// (categories, available_dates) accept a JSON array or a comma separated string.
+var mappableFields = map[string]bool{
+	"name":            true,
+	"external_id":     true,
+	"pool":            true,
+	"categories":      true,
+	"available_dates": true,
+	"gender":          true,
+	"source":          true,
+	"status":          true,
+}
+
+// defaultFormMapping is the shape sent by the original Google Apps Script:
+// { "name": "...", "email": "...", "group": "...", "categories": ["..."], "available_dates": ["..."] }
+func defaultFormMapping(source string) *models.FormMapping {
+	return &models.FormMapping{
+		Source: source,
+		Fields: map[string]string{
+			"name":            "name",
+			"external_id":     "email",
+			"pool":            "group",
+			"categories":      "categories",
+			"available_dates": "available_dates",
+			"gender":          "gender",
+			"source":          "source",
+			"status":          "status",
+		},
+	}
+}
+
+// formMappingFor loads the mapping configured for a source, falling back to the default shape.
+func (h *Handler) formMappingFor(ctx context.Context, source string) (*models.FormMapping, error) {
+	var mapping models.FormMapping
+	err := h.DB.NewSelect().Model(&mapping).Where("source = ?", source).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return defaultFormMapping(source), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+// lookupPath walks a decoded JSON payload with a dotted key, e.g. "answers.0.text".
+func lookupPath(payload map[string]interface{}, path string) interface{} {
+	var cur interface{} = payload
+	for _, part := range strings.Split(path, ".") {
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			cur = node[part]
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			cur = node[i]
+		default:
+			return nil
+		}
+	}
+	return cur
+}
+
+func toStringList(v interface{}) []string {
+	var out []string
+	switch val := v.(type) {
+	case []interface{}:
+		for _, item := range val {
+			if s := strings.TrimSpace(fmt.Sprint(item)); s != "" {
+				out = append(out, s)
+			}
+		}
+	case string:
+		for _, item := range strings.Split(val, ",") {
+			if s := strings.TrimSpace(item); s != "" {
+				out = append(out, s)
+			}
+		}
+	case nil:
+	default:
+		out = append(out, fmt.Sprint(val))
+	}
+	return out
+}
+
+func toString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(val)
+	case []interface{}:
+		return strings.Join(toStringList(val), ", ")
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// translateValue applies a value map, matching raw answers case-insensitively.
+func translateValue(values map[string]string, raw string) string {
+	if len(values) == 0 {
+		return raw
+	}
+	if v, ok := values[raw]; ok {
+		return v
+	}
+	for from, to := range values {
+		if strings.EqualFold(strings.TrimSpace(from), raw) {
+			return to
+		}
+	}
+	return raw
+}
+
+func applyFormMapping(mapping *models.FormMapping, payload map[string]interface{}) FormSubmission {
+	str := func(field string) string {
+		key, ok := mapping.Fields[field]
+		if !ok || key == "" {
+			return ""
+		}
+		return translateValue(mapping.ValueMaps[field], toString(lookupPath(payload, key)))
+	}
+	list := func(field string) []string {
+		key, ok := mapping.Fields[field]
+		if !ok || key == "" {
+			return nil
+		}
+		items := toStringList(lookupPath(payload, key))
+		for i, item := range items {
+			items[i] = translateValue(mapping.ValueMaps[field], item)
+		}
+		return items
+	}
+
+	return FormSubmission{
+		Name:           str("name"),
+		ExternalID:     strings.ToLower(str("external_id")),
+		Pool:           str("pool"),
+		Categories:     list("categories"),
+		AvailableDates: list("available_dates"),
+		Gender:         str("gender"),
+		Source:         str("source"),
+		Status:         str("status"),
+	}
+}
+
+type UpsertFormMappingRequest struct {
+	Fields    map[string]string            `json:"fields" binding:"required"`
+	ValueMaps map[string]map[string]string `json:"value_maps"`
+}
+
+// ListFormMappings returns the configured mappings per registration source
+// GET /api/admin/form-mappings
+func (h *Handler) ListFormMappings(c *gin.Context) {
+	var mappings []models.FormMapping
+	if err := h.DB.NewSelect().Model(&mappings).Order("source ASC").Scan(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings)
+}
+
+// UpsertFormMapping creates or replaces the mapping of one source
+// PUT /api/admin/form-mappings/:source
+func (h *Handler) UpsertFormMapping(c *gin.Context) {
+	var req UpsertFormMappingRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for field := range req.Fields {
+		if !mappableFields[field] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown participant field: " + field})
+			return
+		}
+	}
+	for field := range req.ValueMaps {
+		if !mappableFields[field] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown participant field in value_maps: " + field})
+			return
+		}
+	}
+	if req.Fields["name"] == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A mapping for 'name' is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	source := c.Param("source")
+
+	before, err := h.formMappingFor(ctx, source)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	mapping := &models.FormMapping{
+		Source:    source,
+		Fields:    req.Fields,
+		ValueMaps: req.ValueMaps,
+		UpdatedAt: time.Now(),
+	}
+	_, err = h.DB.NewInsert().Model(mapping).
+		On("CONFLICT (source) DO UPDATE").
+		Set("fields = EXCLUDED.fields").
+		Set("value_maps = EXCLUDED.value_maps").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "UpsertFormMapping", "form_mapping", source, before, mapping)
+
+	c.JSON(http.StatusOK, mapping)
+}
+
+// DeleteFormMapping reverts a source to the default mapping
+// DELETE /api/admin/form-mappings/:source
+func (h *Handler) DeleteFormMapping(c *gin.Context) {
+	ctx := c.Request.Context()
+	source := c.Param("source")
+
+	var mapping models.FormMapping
+	if err := h.DB.NewSelect().Model(&mapping).Where("source = ?", source).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Form mapping not found"})
+		return
+	}
+
+	if _, err := h.DB.NewDelete().Model(&mapping).WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "DeleteFormMapping", "form_mapping", source, mapping, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Form mapping deleted"})
+}
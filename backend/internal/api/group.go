@@ -1,16 +1,15 @@
 package api
 
 import (
-	"context"
-	"fmt"
-	"math/rand"
+	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+	"badminton_tournament/backend/internal/service"
 )
 
 type CreateGroupRequest struct {
@@ -27,82 +26,22 @@ func (h *Handler) CreateGroup(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	if len(req.TeamIDs) != 4 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Group must have exactly 4 teams"})
-		return
-	}
-	if req.Pool == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Pool is required (Mesoneer or Lab)"})
-		return
-	}
-
-	ctx := c.Request.Context()
-
-	// 1. Validate Teams: Must be in same Pool and not busy
-	var teams []models.Team
-	if err := h.DB.NewSelect().Model(&teams).Where("id IN (?)", bun.In(req.TeamIDs)).Scan(ctx); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch teams"})
-		return
-	}
-
-	if len(teams) != 4 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "One or more teams not found"})
+	if !h.requirePhase(c, req.TournamentID, service.OpCreateGroups) {
 		return
 	}
 
-	for _, team := range teams {
-		if team.Pool != req.Pool {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "All teams must belong to the selected Pool (" + req.Pool + ")"})
-			return
-		}
-	}
-
-	// Shuffle Teams for Random Seeding
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(req.TeamIDs), func(i, j int) {
-		req.TeamIDs[i], req.TeamIDs[j] = req.TeamIDs[j], req.TeamIDs[i]
-	})
-
-	// Add Category check for the teams to ensure they belong to this Category
-	for _, team := range teams {
-		if team.Category != req.Category {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "All teams must belong to the selected Category (" + req.Category + ")"})
-			return
-		}
-	}
-
-	// Check if already in active match
-	count, err := h.DB.NewSelect().Model((*models.Match)(nil)).
-		Where("team_a_id IN (?) OR team_b_id IN (?)", bun.In(req.TeamIDs), bun.In(req.TeamIDs)).
-		Count(ctx)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate team availability"})
-		return
-	}
-	if count > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "One or more selected teams are already competing in another group in this category"})
-		return
-	}
-
-	// 2. Create Group
-	group := &models.Group{
-		TournamentID: req.TournamentID,
+	group, seeded, err := h.Service.CreateGroup(c.Request.Context(), service.CreateGroupInput{
 		Name:         req.Name,
 		Pool:         req.Pool,
+		TournamentID: req.TournamentID,
+		TeamIDs:      req.TeamIDs,
 		Category:     req.Category,
-	}
-	_, err = h.DB.NewInsert().Model(group).Returning("*").Exec(ctx)
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create group: " + err.Error()})
-		return
-	}
-
-	// 3. Create GSL Matches
-	if err := h.createGSLMatches(ctx, group.ID, req.TeamIDs); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create matches: " + err.Error()})
+		serviceError(c, err)
 		return
 	}
+	h.recordAudit(c, "CreateGroup", "group", group.ID.String(), nil, gin.H{"group": group, "team_ids": seeded})
 
 	c.JSON(http.StatusOK, gin.H{"group_id": group.ID, "status": "created"})
 }
@@ -120,78 +59,22 @@ func (h *Handler) AutoGenerateGroups(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	if req.Pool == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Pool is required"})
+	if !h.requirePhase(c, req.TournamentID, service.OpCreateGroups) {
 		return
 	}
 
-	ctx := c.Request.Context()
-
-	// Fetch available teams in pool
-	var availableTeams []models.Team
-	err := h.DB.NewSelect().
-		Model(&availableTeams).
-		Where("pool = ?", req.Pool).
-		Where("category = ?", req.Category).
-		Where("id NOT IN (SELECT team_a_id FROM matches WHERE team_a_id IS NOT NULL UNION SELECT team_b_id FROM matches WHERE team_b_id IS NOT NULL)").
-		Scan(ctx)
-
+	createdGroups, err := h.Service.AutoGenerateGroups(c.Request.Context(), service.AutoGenerateGroupsInput{
+		Pool:         req.Pool,
+		TournamentID: req.TournamentID,
+		NamePrefix:   req.NamePrefix,
+		Category:     req.Category,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch available teams: " + err.Error()})
-		return
-	}
-
-	numTeams := len(availableTeams)
-	if numTeams == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No available teams in " + req.Pool + " pool"})
+		serviceError(c, err)
 		return
 	}
 
-	if numTeams%4 != 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot auto-generate: " + fmt.Sprintf("%d", numTeams) + " teams available, but groups must have exactly 4 teams"})
-		return
-	}
-
-	// Shuffle
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(numTeams, func(i, j int) {
-		availableTeams[i], availableTeams[j] = availableTeams[j], availableTeams[i]
-	})
-
-	var createdGroups []uuid.UUID
-	for i := 0; i < numTeams; i += 4 {
-		name := req.NamePrefix
-		if name == "" {
-			name = "Group"
-		}
-		name = fmt.Sprintf("%s %d", name, (i/4)+1)
-
-		group := &models.Group{
-			TournamentID: req.TournamentID,
-			Name:         name,
-			Pool:         req.Pool,
-			Category:     req.Category,
-		}
-		_, err := h.DB.NewInsert().Model(group).Returning("*").Exec(ctx)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create group: " + err.Error()})
-			return
-		}
-
-		teamIDs := []uuid.UUID{
-			availableTeams[i].ID,
-			availableTeams[i+1].ID,
-			availableTeams[i+2].ID,
-			availableTeams[i+3].ID,
-		}
-
-		if err := h.createGSLMatches(ctx, group.ID, teamIDs); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create matches for group " + name + ": " + err.Error()})
-			return
-		}
-		createdGroups = append(createdGroups, group.ID)
-	}
+	h.recordAudit(c, "AutoGenerateGroups", "group", "", nil, gin.H{"request": req, "group_ids": createdGroups})
 
 	c.JSON(http.StatusOK, gin.H{
 		"status":         "created",
@@ -200,53 +83,24 @@ func (h *Handler) AutoGenerateGroups(c *gin.Context) {
 	})
 }
 
-func (h *Handler) createGSLMatches(ctx context.Context, groupID uuid.UUID, teamIDs []uuid.UUID) error {
-	// M5 (Decider)
-	m5 := &models.Match{GroupID: groupID, Label: "Decider"}
-	_, err := h.DB.NewInsert().Model(m5).Exec(ctx)
-	if err != nil {
-		return err
-	}
-
-	// M3 (Winners Match)
-	m3 := &models.Match{GroupID: groupID, Label: "Winners", NextMatchLoseID: m5.ID}
-	_, err = h.DB.NewInsert().Model(m3).Exec(ctx)
-	if err != nil {
-		return err
-	}
-
-	// M4 (Losers Match)
-	m4 := &models.Match{GroupID: groupID, Label: "Losers", NextMatchWinID: m5.ID}
-	_, err = h.DB.NewInsert().Model(m4).Exec(ctx)
-	if err != nil {
-		return err
-	}
-
-	// M1 (Opening A)
-	m1 := &models.Match{
-		GroupID:         groupID,
-		Label:           "M1",
-		TeamAID:         teamIDs[0],
-		TeamBID:         teamIDs[1],
-		NextMatchWinID:  m3.ID,
-		NextMatchLoseID: m4.ID,
+// serviceError reports a tournament rule violation as 400, an operation the tournament's
+// phase does not allow as 409, a missing row as 404 and anything else as 500.
+func serviceError(c *gin.Context, err error) {
+	var verr service.ValidationError
+	if errors.As(err, &verr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": verr.Error()})
+		return
 	}
-	_, err = h.DB.NewInsert().Model(m1).Exec(ctx)
-	if err != nil {
-		return err
+	var perr *service.PhaseError
+	if errors.As(err, &perr) {
+		c.JSON(http.StatusConflict, gin.H{"error": perr.Error(), "phase": perr.Phase})
+		return
 	}
-
-	// M2 (Opening B)
-	m2 := &models.Match{
-		GroupID:         groupID,
-		Label:           "M2",
-		TeamAID:         teamIDs[2],
-		TeamBID:         teamIDs[3],
-		NextMatchWinID:  m3.ID,
-		NextMatchLoseID: m4.ID,
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
 	}
-	_, err = h.DB.NewInsert().Model(m2).Exec(ctx)
-	return err
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
 
 func (h *Handler) ListGroups(c *gin.Context) {
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"badminton_tournament/backend/internal/db"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestHandler returns a handler on a fresh, fully migrated SQLite database.
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	t.Setenv("DATABASE_URL", "sqlite://"+filepath.Join(t.TempDir(), "test.db"))
+	if err := db.Connect(); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.DB.Close() })
+	if err := db.Migrate(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewHandler(db.DB, nil)
+}
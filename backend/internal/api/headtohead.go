@@ -0,0 +1,285 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+// Meeting is one decided match between the two sides, with teams, score and games seen
+// from side A.
+type Meeting struct {
+	MatchID      uuid.UUID      `json:"match_id"`
+	TournamentID uuid.UUID      `json:"tournament_id"`
+	Tournament   string         `json:"tournament"`
+	Category     string         `json:"category"`
+	Group        string         `json:"group"`
+	Label        string         `json:"label"`
+	TeamA        string         `json:"team_a"`
+	TeamB        string         `json:"team_b"`
+	Winner       string         `json:"winner"` // "A" or "B"
+	Score        string         `json:"score"`
+	Games        []service.Game `json:"games,omitempty"`
+	Walkover     bool           `json:"walkover,omitempty"`
+}
+
+type HeadToHeadRecord struct {
+	Meetings int `json:"meetings"`
+	WinsA    int `json:"wins_a"`
+	WinsB    int `json:"wins_b"`
+	SetsA    int `json:"sets_a"`
+	SetsB    int `json:"sets_b"`
+	PointsA  int `json:"points_a"`
+	PointsB  int `json:"points_b"`
+}
+
+type HeadToHead struct {
+	A        string           `json:"a"`
+	B        string           `json:"b"`
+	Record   HeadToHeadRecord `json:"record"`
+	Meetings []Meeting        `json:"meetings"`
+}
+
+// GetHeadToHead lists every previous meeting of two teams, or of two participants across all
+// the teams they played in, oldest first
+// GET /api/head-to-head?team_a=<uuid>&team_b=<uuid>
+// GET /api/head-to-head?participant_a=<uuid>&participant_b=<uuid>
+func (h *Handler) GetHeadToHead(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if a := c.Query("team_a") + c.Query("participant_a"); a != "" && a == c.Query("team_b")+c.Query("participant_b") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "the two sides must differ"})
+		return
+	}
+
+	var sideA, sideB []uuid.UUID
+	var nameA, nameB string
+	var err error
+	switch {
+	case c.Query("team_a") != "" || c.Query("team_b") != "":
+		sideA, nameA, err = h.headToHeadTeam(ctx, c.Query("team_a"))
+		if err == nil {
+			sideB, nameB, err = h.headToHeadTeam(ctx, c.Query("team_b"))
+		}
+	case c.Query("participant_a") != "" || c.Query("participant_b") != "":
+		sideA, nameA, err = h.headToHeadParticipant(ctx, c.Query("participant_a"))
+		if err == nil {
+			sideB, nameB, err = h.headToHeadParticipant(ctx, c.Query("participant_b"))
+		}
+		// Teams they formed together never play against either of them
+		sideA, sideB = without(sideA, sideB), without(sideB, sideA)
+	default:
+		err = fmt.Errorf("give team_a and team_b, or participant_a and participant_b")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := HeadToHead{A: nameA, B: nameB, Meetings: []Meeting{}}
+	if len(sideA) == 0 || len(sideB) == 0 {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	var matches []models.Match
+	if err := h.DB.NewSelect().Model(&matches).
+		Where("winner_id IS NOT NULL").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereOr("team_a_id IN (?) AND team_b_id IN (?)", bun.In(sideA), bun.In(sideB)).
+				WhereOr("team_a_id IN (?) AND team_b_id IN (?)", bun.In(sideB), bun.In(sideA))
+		}).
+		Scan(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(matches) == 0 {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	groupIDs := make([]uuid.UUID, 0, len(matches))
+	teamIDs := make([]uuid.UUID, 0, 2*len(matches))
+	for _, m := range matches {
+		groupIDs = append(groupIDs, m.GroupID)
+		teamIDs = append(teamIDs, m.TeamAID, m.TeamBID)
+	}
+	var groups []models.Group
+	if err := h.DB.NewSelect().Model(&groups).Where("id IN (?)", bun.In(groupIDs)).Scan(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	groupByID := make(map[uuid.UUID]*models.Group, len(groups))
+	tournamentIDs := make([]uuid.UUID, 0, len(groups))
+	for i := range groups {
+		groupByID[groups[i].ID] = &groups[i]
+		tournamentIDs = append(tournamentIDs, groups[i].TournamentID)
+	}
+	var tournaments []models.Tournament
+	if err := h.DB.NewSelect().Model(&tournaments).Where("id IN (?)", bun.In(tournamentIDs)).Scan(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	tournamentByID := make(map[uuid.UUID]models.Tournament, len(tournaments))
+	for _, t := range tournaments {
+		tournamentByID[t.ID] = t
+	}
+	var teams []models.Team
+	if err := h.DB.NewSelect().Model(&teams).Column("id", "name").Where("id IN (?)", bun.In(teamIDs)).Scan(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	names := make(map[uuid.UUID]string, len(teams))
+	for _, t := range teams {
+		names[t.ID] = t.Name
+	}
+
+	kept := matches[:0]
+	for _, m := range matches {
+		if groupByID[m.GroupID] != nil {
+			kept = append(kept, m)
+		}
+	}
+	matches = kept
+
+	// Groups of deleted tournaments still sort, as if started at the zero time
+	start := func(m *models.Match) time.Time {
+		return tournamentByID[groupByID[m.GroupID].TournamentID].CreatedAt
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := &matches[i], &matches[j]
+		return playedBefore(start(a), start(b), groupByID[a.GroupID], groupByID[b.GroupID], a, b)
+	})
+
+	inA := make(map[uuid.UUID]bool, len(sideA))
+	for _, id := range sideA {
+		inA[id] = true
+	}
+	for i := range matches {
+		m := &matches[i]
+		g := groupByID[m.GroupID]
+		flip := !inA[m.TeamAID]
+		teamA, teamB := m.TeamAID, m.TeamBID
+		if flip {
+			teamA, teamB = teamB, teamA
+		}
+
+		games, _ := matchGames(m)
+		meeting := Meeting{
+			MatchID:      m.ID,
+			TournamentID: g.TournamentID,
+			Tournament:   tournamentByID[g.TournamentID].Name,
+			Category:     g.Category,
+			Group:        g.Name,
+			Label:        m.Label,
+			TeamA:        names[teamA],
+			TeamB:        names[teamB],
+			Winner:       "A",
+			Score:        m.Score,
+			Walkover:     m.Score == walkoverScore,
+		}
+		if m.WinnerID == teamB {
+			meeting.Winner = "B"
+		}
+		if flip {
+			meeting.Score = swapScore(m.Score)
+		}
+		for _, game := range games {
+			if flip {
+				game.A, game.B = game.B, game.A
+			}
+			meeting.Games = append(meeting.Games, game)
+		}
+
+		r := &result.Record
+		r.Meetings++
+		if meeting.Winner == "A" {
+			r.WinsA++
+		} else {
+			r.WinsB++
+		}
+		for _, game := range meeting.Games {
+			r.PointsA += game.A
+			r.PointsB += game.B
+			switch service.GameWinner(game.A, game.B) {
+			case "A":
+				r.SetsA++
+			case "B":
+				r.SetsB++
+			}
+		}
+		result.Meetings = append(result.Meetings, meeting)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) headToHeadTeam(ctx context.Context, raw string) ([]uuid.UUID, string, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid team ID %q", raw)
+	}
+	var team models.Team
+	if err := h.DB.NewSelect().Model(&team).Column("id", "name").Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, "", fmt.Errorf("team %s not found", id)
+	}
+	return []uuid.UUID{team.ID}, team.Name, nil
+}
+
+// headToHeadParticipant returns every team the participant has played in.
+func (h *Handler) headToHeadParticipant(ctx context.Context, raw string) ([]uuid.UUID, string, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid participant ID %q", raw)
+	}
+	var p models.Participant
+	if err := h.DB.NewSelect().Model(&p).Column("id", "name").Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, "", fmt.Errorf("participant %s not found", id)
+	}
+	var teamIDs []uuid.UUID
+	if err := h.DB.NewSelect().Model((*models.Team)(nil)).Column("id").
+		Where("player1_id = ? OR player2_id = ?", id, id).
+		Scan(ctx, &teamIDs); err != nil {
+		return nil, "", err
+	}
+	return teamIDs, p.Name, nil
+}
+
+// without returns ids minus the ones in exclude.
+func without(ids, exclude []uuid.UUID) []uuid.UUID {
+	skip := make(map[uuid.UUID]bool, len(exclude))
+	for _, id := range exclude {
+		skip[id] = true
+	}
+	var out []uuid.UUID
+	for _, id := range ids {
+		if !skip[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// swapScore turns "2-1" into "1-2" and "21-19, 15-21" into "19-21, 21-15". Anything else,
+// such as a walkover, is returned unchanged.
+func swapScore(score string) string {
+	parts := strings.Split(score, ",")
+	for i, part := range parts {
+		var a, b int
+		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%d-%d", &a, &b); err != nil {
+			return score
+		}
+		parts[i] = fmt.Sprintf("%d-%d", b, a)
+	}
+	return strings.Join(parts, ", ")
+}
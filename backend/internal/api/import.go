@@ -0,0 +1,411 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uptrace/bun"
+	"github.com/xuri/excelize/v2"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+const maxImportBytes = 5 << 20
+
+var (
+	validPools      = []string{"Mesoneer", "Lab"}
+	validCategories = []string{"MensDoubles", "MixedDoubles"}
+)
+
+// importColumns maps accepted header spellings onto participant fields.
+var importColumns = map[string]string{
+	"name":            "name",
+	"full name":       "name",
+	"họ và tên":       "name",
+	"email":           "external_id",
+	"external_id":     "external_id",
+	"employee id":     "external_id",
+	"pool":            "pool",
+	"group":           "pool",
+	"gender":          "gender",
+	"giới tính":       "gender",
+	"categories":      "categories",
+	"category":        "categories",
+	"available_dates": "available_dates",
+	"available dates": "available_dates",
+	"dates":           "available_dates",
+	"status":          "status",
+}
+
+// ImportRow is the validation result and planned change for one spreadsheet row.
+type ImportRow struct {
+	Row         int                 `json:"row"` // 1-based, header is row 1
+	Name        string              `json:"name"`
+	Action      string              `json:"action,omitempty"` // "create", "update", "unchanged"
+	Changes     map[string][]string `json:"changes,omitempty"` // field -> [old, new]
+	Errors      []string            `json:"errors,omitempty"`
+	participant *models.Participant
+	columns     []string // columns written on an existing participant
+}
+
+type ImportReport struct {
+	DryRun  bool           `json:"dry_run"`
+	Applied bool           `json:"applied"`
+	Columns []string       `json:"columns"` // fields the file sets; the others are left unchanged
+	Summary map[string]int `json:"summary"`
+	Rows    []ImportRow    `json:"rows"`
+}
+
+// readImportRows returns all rows of a CSV (comma or semicolon separated) or the first sheet of an XLSX.
+func readImportRows(filename string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xlsx":
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return f.GetRows(f.GetSheetName(0))
+	case ".csv", "":
+		data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // Excel's UTF-8 BOM
+		r := csv.NewReader(bytes.NewReader(data))
+		firstLine, _, _ := strings.Cut(string(data), "\n")
+		if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+			r.Comma = ';'
+		}
+		r.FieldsPerRecord = -1
+		return r.ReadAll()
+	default:
+		return nil, fmt.Errorf("unsupported file type %q (use .csv or .xlsx)", filepath.Ext(filename))
+	}
+}
+
+func canonicalChoice(value string, choices []string) (string, bool) {
+	for _, c := range choices {
+		if strings.EqualFold(strings.TrimSpace(value), c) {
+			return c, true
+		}
+	}
+	return value, false
+}
+
+// parseImportDate accepts ISO dates and the Vietnamese day-first format, returning YYYY-MM-DD.
+func parseImportDate(value string) (string, error) {
+	for _, layout := range []string{"2006-01-02", "02/01/2006", "2/1/2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q (use YYYY-MM-DD or DD/MM/YYYY)", value)
+}
+
+func splitList(value string) []string {
+	var out []string
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '|' }) {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// validateImportRow turns one row into a participant, collecting every problem instead of stopping at the first.
+// Fields without a column in the file are left empty and not validated.
+func validateImportRow(columns map[int]string, present map[string]bool, record []string) (*models.Participant, []string) {
+	fields := make(map[string]string)
+	for i, value := range record {
+		if field, ok := columns[i]; ok {
+			fields[field] = strings.TrimSpace(value)
+		}
+	}
+
+	var errs []string
+	p := &models.Participant{
+		Name:       fields["name"],
+		ExternalID: strings.ToLower(fields["external_id"]),
+		Source:     "import",
+		Status:     fields["status"],
+	}
+
+	if p.Name == "" {
+		errs = append(errs, "name is required")
+	}
+
+	if present["pool"] {
+		pool, ok := canonicalChoice(fields["pool"], validPools)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("pool must be one of %s", strings.Join(validPools, ", ")))
+		}
+		p.Pool = pool
+	}
+
+	switch {
+	case fields["gender"] == "":
+	case service.IsMale(fields["gender"]):
+		p.Gender = "Male"
+	case service.IsFemale(fields["gender"]):
+		p.Gender = "Female"
+	default:
+		errs = append(errs, fmt.Sprintf("unknown gender %q", fields["gender"]))
+	}
+
+	for _, cat := range splitList(fields["categories"]) {
+		canonical, ok := canonicalChoice(cat, validCategories)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown category %q (use %s)", cat, strings.Join(validCategories, ", ")))
+			continue
+		}
+		p.Categories = append(p.Categories, canonical)
+	}
+	if p.Gender == "Female" {
+		for _, cat := range p.Categories {
+			if cat == "MensDoubles" {
+				errs = append(errs, "MensDoubles requires a male player")
+			}
+		}
+	}
+
+	for _, d := range splitList(fields["available_dates"]) {
+		date, err := parseImportDate(d)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		p.AvailableDates = append(p.AvailableDates, date)
+	}
+
+	return p, errs
+}
+
+// importUpdateColumns lists the columns a row writes on an existing participant: those the file
+// has a column for. An empty status cell keeps the current status.
+func importUpdateColumns(present map[string]bool, p *models.Participant) []string {
+	columns := []string{"source"}
+	for _, field := range []string{"name", "pool", "gender", "categories", "available_dates", "status"} {
+		if present[field] && (field != "status" || p.Status != "") {
+			columns = append(columns, field)
+		}
+	}
+	return columns
+}
+
+// diffParticipant lists the fields an import would change on an existing participant, limited
+// to the columns it writes.
+func diffParticipant(old, new *models.Participant, columns []string) map[string][]string {
+	changes := make(map[string][]string)
+	add := func(field, a, b string) {
+		if a != b {
+			changes[field] = []string{a, b}
+		}
+	}
+	if new.ExternalID != "" {
+		add("external_id", old.ExternalID, new.ExternalID)
+	}
+	for _, col := range columns {
+		switch col {
+		case "name":
+			add("name", old.Name, new.Name)
+		case "pool":
+			add("pool", old.Pool, new.Pool)
+		case "gender":
+			add("gender", old.Gender, new.Gender)
+		case "status":
+			add("status", old.Status, new.Status)
+		case "categories":
+			if !reflect.DeepEqual(old.Categories, new.Categories) {
+				changes["categories"] = []string{strings.Join(old.Categories, ", "), strings.Join(new.Categories, ", ")}
+			}
+		case "available_dates":
+			if !reflect.DeepEqual(old.AvailableDates, new.AvailableDates) {
+				changes["available_dates"] = []string{strings.Join(old.AvailableDates, ", "), strings.Join(new.AvailableDates, ", ")}
+			}
+		}
+	}
+	return changes
+}
+
+// findExistingParticipant mirrors upsertParticipant's matching: external ID first, then name.
+func (h *Handler) findExistingParticipant(ctx context.Context, p *models.Participant) (*models.Participant, error) {
+	var existing models.Participant
+	if p.ExternalID != "" {
+		err := h.DB.NewSelect().Model(&existing).Where("external_id = ?", p.ExternalID).Scan(ctx)
+		if err == nil {
+			return &existing, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	err := h.DB.NewSelect().Model(&existing).Where("name = ?", p.Name).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+func (h *Handler) buildImportReport(ctx context.Context, records [][]string) (*ImportReport, error) {
+	report := &ImportReport{Summary: map[string]int{"create": 0, "update": 0, "unchanged": 0, "invalid": 0}}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("file must have a header row and at least one data row")
+	}
+
+	columns := make(map[int]string)
+	present := make(map[string]bool)
+	for i, header := range records[0] {
+		if field, ok := importColumns[strings.ToLower(strings.TrimSpace(header))]; ok {
+			columns[i] = field
+			if !present[field] {
+				present[field] = true
+				report.Columns = append(report.Columns, field)
+			}
+		}
+	}
+	if !present["name"] {
+		return nil, fmt.Errorf("header row must contain a name column")
+	}
+
+	seen := make(map[string]int)
+	for i, record := range records[1:] {
+		rowNum := i + 2
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		p, errs := validateImportRow(columns, present, record)
+		row := ImportRow{Row: rowNum, Name: p.Name, Errors: errs, participant: p, columns: importUpdateColumns(present, p)}
+
+		key := models.NormalizeName(p.Name)
+		if first, dup := seen[key]; dup && key != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("duplicate of row %d", first))
+		} else {
+			seen[key] = rowNum
+		}
+
+		if len(row.Errors) > 0 {
+			report.Summary["invalid"]++
+			report.Rows = append(report.Rows, row)
+			continue
+		}
+
+		existing, err := h.findExistingParticipant(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case existing != nil && identityConflict(existing, p):
+			row.Errors = append(row.Errors, fmt.Sprintf("name is already registered to a participant with external ID %s", existing.ExternalID))
+			report.Summary["invalid"]++
+			report.Rows = append(report.Rows, row)
+			continue
+		case existing == nil && !present["pool"]:
+			row.Errors = append(row.Errors, "pool is required for new participants")
+			report.Summary["invalid"]++
+			report.Rows = append(report.Rows, row)
+			continue
+		case existing == nil:
+			row.Action = "create"
+		default:
+			row.Changes = diffParticipant(existing, p, row.columns)
+			if len(row.Changes) == 0 {
+				row.Action = "unchanged"
+			} else {
+				row.Action = "update"
+			}
+		}
+		report.Summary[row.Action]++
+		report.Rows = append(report.Rows, row)
+	}
+
+	return report, nil
+}
+
+// ImportParticipants validates a CSV/XLSX of registrations and, unless dry_run=true, upserts all
+// rows in one transaction. Nothing is written if any row is invalid. Existing participants only
+// change in the columns the file has.
+// POST /api/admin/participants/import (multipart field "file")
+func (h *Handler) ImportParticipants(c *gin.Context) {
+	dryRun := c.Query("dry_run") == "true"
+	if !dryRun && !h.requirePhase(c, DefaultTournamentID, service.OpRegister) {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Multipart field 'file' is required"})
+		return
+	}
+	if fileHeader.Size > maxImportBytes {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is too large"})
+		return
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	records, err := readImportRows(fileHeader.Filename, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse file: " + err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.buildImportReport(ctx, records)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report.DryRun = dryRun
+
+	if dryRun {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	if report.Summary["invalid"] > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	err = h.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txh := h.withTx(tx)
+		for _, row := range report.Rows {
+			if row.Action == "unchanged" {
+				continue
+			}
+			if err := txh.upsertParticipant(ctx, row.participant, row.columns); err != nil {
+				return fmt.Errorf("row %d (%s): %w", row.Row, row.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Import rolled back: " + err.Error()})
+		return
+	}
+
+	report.Applied = true
+	h.recordAudit(c, "ImportParticipants", "participant", "", nil, gin.H{"file": fileHeader.Filename, "summary": report.Summary})
+
+	c.JSON(http.StatusOK, report)
+}
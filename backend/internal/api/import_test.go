@@ -0,0 +1,279 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+// csvRecords splits a CSV written one row per line, without quoting.
+func csvRecords(lines ...string) [][]string {
+	records := make([][]string, len(lines))
+	for i, line := range lines {
+		records[i] = strings.Split(line, ",")
+	}
+	return records
+}
+
+func createParticipant(t *testing.T, h *Handler, p models.Participant) {
+	t.Helper()
+	if _, err := h.DB.NewInsert().Model(&p).Exec(context.Background()); err != nil {
+		t.Fatalf("create participant %s: %v", p.Name, err)
+	}
+}
+
+func TestBuildImportReport(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     [][]string
+		wantActions []string // per row; "" for an invalid row
+		wantErrors  []string // first error per row
+	}{
+		{
+			name:        "pools and categories are matched case-insensitively",
+			records:     csvRecords("Name,Pool,Gender,Categories", "Anh,lab,male,mensdoubles;MIXEDDOUBLES"),
+			wantActions: []string{"create"},
+			wantErrors:  []string{""},
+		},
+		{
+			name:        "unknown pool",
+			records:     csvRecords("Name,Pool", "Anh,Marketing"),
+			wantActions: []string{""},
+			wantErrors:  []string{"pool must be one of Mesoneer, Lab"},
+		},
+		{
+			name:        "empty pool",
+			records:     csvRecords("Name,Pool", "Anh,"),
+			wantActions: []string{""},
+			wantErrors:  []string{"pool must be one of Mesoneer, Lab"},
+		},
+		{
+			name:        "unknown category",
+			records:     csvRecords("Name,Pool,Categories", "Anh,Lab,Singles"),
+			wantActions: []string{""},
+			wantErrors:  []string{`unknown category "Singles" (use MensDoubles, MixedDoubles)`},
+		},
+		{
+			name:        "men's doubles needs a male player",
+			records:     csvRecords("Name,Pool,Gender,Categories", "Chi,Lab,nữ,MensDoubles"),
+			wantActions: []string{""},
+			wantErrors:  []string{"MensDoubles requires a male player"},
+		},
+		{
+			name:        "new participant without a pool column",
+			records:     csvRecords("Name,Gender", "Anh,male"),
+			wantActions: []string{""},
+			wantErrors:  []string{"pool is required for new participants"},
+		},
+		{
+			name:        "duplicate rows are matched on the normalized name",
+			records:     csvRecords("Name,Pool", "Nguyễn Văn Anh,Lab", "Dung,Lab", "  nguyen van  ANH ,Mesoneer"),
+			wantActions: []string{"create", "create", ""},
+			wantErrors:  []string{"", "", "duplicate of row 2"},
+		},
+		{
+			name:        "existing participants are updated in the file's columns only",
+			records:     csvRecords("Name,Gender", "Binh,female", "Khoa,male"),
+			wantActions: []string{"update", "unchanged"},
+			wantErrors:  []string{"", ""},
+		},
+		{
+			name:        "a name registered to another external ID",
+			records:     csvRecords("Name,Email,Pool", "Binh,someone@example.com,Lab"),
+			wantActions: []string{""},
+			wantErrors:  []string{"name is already registered to a participant with external ID binh@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t)
+			createParticipant(t, h, models.Participant{Name: "Binh", ExternalID: "binh@example.com", Pool: "Mesoneer", Gender: "Male"})
+			createParticipant(t, h, models.Participant{Name: "Khoa", Pool: "Lab", Gender: "Male"})
+
+			report, err := h.buildImportReport(context.Background(), tt.records)
+			if err != nil {
+				t.Fatalf("buildImportReport: %v", err)
+			}
+			if len(report.Rows) != len(tt.wantActions) {
+				t.Fatalf("got %d rows, want %d: %+v", len(report.Rows), len(tt.wantActions), report.Rows)
+			}
+			invalid := 0
+			for i, row := range report.Rows {
+				if row.Action != tt.wantActions[i] {
+					t.Errorf("row %d action = %q, want %q", row.Row, row.Action, tt.wantActions[i])
+				}
+				var firstErr string
+				if len(row.Errors) > 0 {
+					firstErr = row.Errors[0]
+					invalid++
+				}
+				if firstErr != tt.wantErrors[i] {
+					t.Errorf("row %d errors = %q, want first %q", row.Row, row.Errors, tt.wantErrors[i])
+				}
+			}
+			if report.Summary["invalid"] != invalid {
+				t.Errorf("summary = %v, want %d invalid", report.Summary, invalid)
+			}
+		})
+	}
+}
+
+func TestBuildImportReportChanges(t *testing.T) {
+	h := newTestHandler(t)
+	createParticipant(t, h, models.Participant{Name: "Binh", Pool: "Mesoneer", Gender: "Male", Status: "withdrawn"})
+
+	report, err := h.buildImportReport(context.Background(), csvRecords("Name,Pool,Status", "Binh,Lab,"))
+	if err != nil {
+		t.Fatalf("buildImportReport: %v", err)
+	}
+	row := report.Rows[0]
+	// Gender has no column and an empty status keeps the current one
+	if want := map[string][]string{"pool": {"Mesoneer", "Lab"}}; !reflect.DeepEqual(row.Changes, want) {
+		t.Errorf("changes = %v, want %v", row.Changes, want)
+	}
+	if want := []string{"source", "name", "pool"}; !reflect.DeepEqual(row.columns, want) {
+		t.Errorf("columns = %v, want %v", row.columns, want)
+	}
+}
+
+func TestBuildImportReportHeader(t *testing.T) {
+	h := newTestHandler(t)
+	for _, records := range [][][]string{
+		csvRecords("Name,Pool"),
+		csvRecords("Email,Pool", "anh@example.com,Lab"),
+	} {
+		if _, err := h.buildImportReport(context.Background(), records); err == nil {
+			t.Errorf("buildImportReport(%q) succeeded, want an error", records)
+		}
+	}
+}
+
+// importFile posts a CSV to ImportParticipants and decodes the report.
+func importFile(t *testing.T, h *Handler, query, content string) (int, ImportReport) {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "participants.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(content))
+	form.Close()
+
+	r := gin.New()
+	r.POST("/import", h.ImportParticipants)
+	req := httptest.NewRequest(http.MethodPost, "/import"+query, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var report ImportReport
+	if w.Code == http.StatusOK || w.Code == http.StatusUnprocessableEntity {
+		if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+			t.Fatalf("decode report: %v (%s)", err, w.Body.String())
+		}
+	}
+	return w.Code, report
+}
+
+func setPhase(t *testing.T, h *Handler, phase string) {
+	t.Helper()
+	_, err := h.DB.NewUpdate().Model((*models.Tournament)(nil)).
+		Set("status = ?", phase).
+		Where("id = ?", DefaultTournamentID).
+		Exec(context.Background())
+	if err != nil {
+		t.Fatalf("set phase: %v", err)
+	}
+}
+
+func participantsByName(t *testing.T, h *Handler) map[string]models.Participant {
+	t.Helper()
+	var participants []models.Participant
+	if err := h.DB.NewSelect().Model(&participants).Scan(context.Background()); err != nil {
+		t.Fatalf("list participants: %v", err)
+	}
+	byName := make(map[string]models.Participant, len(participants))
+	for _, p := range participants {
+		byName[p.Name] = p
+	}
+	return byName
+}
+
+func TestImportParticipants(t *testing.T) {
+	const file = "Name,Pool,Gender\nAnh,Lab,male\nBinh,Lab,\n"
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		h := newTestHandler(t)
+		createParticipant(t, h, models.Participant{Name: "Binh", Pool: "Mesoneer", Gender: "Male"})
+
+		code, report := importFile(t, h, "?dry_run=true", file)
+		if code != http.StatusOK || !report.DryRun || report.Applied {
+			t.Fatalf("dry run = %d, dry_run %v, applied %v; want 200 and not applied", code, report.DryRun, report.Applied)
+		}
+		if report.Summary["create"] != 1 || report.Summary["update"] != 1 {
+			t.Errorf("summary = %v, want 1 create and 1 update", report.Summary)
+		}
+		participants := participantsByName(t, h)
+		if _, ok := participants["Anh"]; ok || participants["Binh"].Pool != "Mesoneer" {
+			t.Errorf("dry run changed participants: %+v", participants)
+		}
+	})
+
+	t.Run("commit applies every row", func(t *testing.T) {
+		h := newTestHandler(t)
+		setPhase(t, h, service.PhaseRegistration)
+		createParticipant(t, h, models.Participant{Name: "Binh", Pool: "Mesoneer", Gender: "Male"})
+
+		code, report := importFile(t, h, "", file)
+		if code != http.StatusOK || report.DryRun || !report.Applied {
+			t.Fatalf("import = %d, dry_run %v, applied %v; want 200 and applied", code, report.DryRun, report.Applied)
+		}
+		participants := participantsByName(t, h)
+		if p, ok := participants["Anh"]; !ok || p.Pool != "Lab" || p.Gender != "Male" || p.Source != "import" {
+			t.Errorf("Anh = %+v, want a new Lab player from the import", p)
+		}
+		// An empty gender cell clears the gender, since the file has that column
+		if p := participants["Binh"]; p.Pool != "Lab" || p.Gender != "" {
+			t.Errorf("Binh = %+v, want moved to Lab without a gender", p)
+		}
+	})
+
+	t.Run("an invalid row blocks the whole commit", func(t *testing.T) {
+		h := newTestHandler(t)
+		setPhase(t, h, service.PhaseRegistration)
+
+		code, report := importFile(t, h, "", file+"Anh,Mesoneer,male\n")
+		if code != http.StatusUnprocessableEntity || report.Applied {
+			t.Fatalf("import = %d, applied %v; want 422 and not applied", code, report.Applied)
+		}
+		if len(report.Rows) != 3 || report.Rows[2].Errors[0] != "duplicate of row 2" {
+			t.Errorf("rows = %+v, want row 4 flagged as a duplicate of row 2", report.Rows)
+		}
+		if participants := participantsByName(t, h); len(participants) != 0 {
+			t.Errorf("rejected import wrote %d participants", len(participants))
+		}
+	})
+
+	t.Run("commit needs the registration phase, a dry run does not", func(t *testing.T) {
+		h := newTestHandler(t)
+		setPhase(t, h, service.PhaseGroups)
+
+		if code, _ := importFile(t, h, "", file); code != http.StatusConflict {
+			t.Errorf("import during the groups phase = %d, want 409", code)
+		}
+		if code, _ := importFile(t, h, "?dry_run=true", file); code != http.StatusOK {
+			t.Errorf("dry run during the groups phase = %d, want 200", code)
+		}
+	})
+}
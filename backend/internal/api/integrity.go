@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/service"
+)
+
+// CheckIntegrity lists bracket inconsistencies without changing anything.
+// GET /api/admin/integrity
+func (h *Handler) CheckIntegrity(c *gin.Context) {
+	report, err := h.Service.CheckIntegrity(c.Request.Context(), false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// RepairIntegrity fixes the repairable issues in one transaction and reports what is left.
+// POST /api/admin/integrity/repair
+func (h *Handler) RepairIntegrity(c *gin.Context) {
+	var report *service.IntegrityReport
+	err := h.DB.RunInTx(c.Request.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		report, err = h.withTx(tx).Service.CheckIntegrity(ctx, true)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Repair rolled back: " + err.Error()})
+		return
+	}
+
+	var repaired []service.IntegrityIssue
+	for _, issue := range report.Issues {
+		if issue.Repaired {
+			repaired = append(repaired, issue)
+		}
+	}
+	if len(repaired) > 0 {
+		h.recordAudit(c, "RepairIntegrity", "tournament", "", nil, repaired)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
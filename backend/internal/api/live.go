@@ -0,0 +1,345 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/db"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+var (
+	errMatchFinished = errors.New("match is already finished")
+	errInvalidSide   = errors.New("side must be \"A\" or \"B\"")
+)
+
+// SetScore is the point score of a single game.
+type SetScore struct {
+	A      int    `json:"a"`
+	B      int    `json:"b"`
+	Winner string `json:"winner,omitempty"` // "A", "B" or empty while in progress
+}
+
+// LiveState is the current state of a match in umpire mode, derived from its rally events.
+type LiveState struct {
+	MatchID      uuid.UUID  `json:"match_id"`
+	BestOf       int        `json:"best_of"`
+	Sets         []SetScore `json:"sets"`
+	CurrentSet   int        `json:"current_set"` // 1-based
+	SetsWonA     int        `json:"sets_won_a"`
+	SetsWonB     int        `json:"sets_won_b"`
+	Server       string     `json:"server"`        // Side to serve the next rally
+	ServiceCourt string     `json:"service_court"` // "right" on an even server score, "left" on odd
+	Rallies      int        `json:"rallies"`
+	Winner       string     `json:"winner,omitempty"` // "A" or "B" once the match is decided
+}
+
+func normalizeSide(side string) (string, error) {
+	side = strings.ToUpper(strings.TrimSpace(side))
+	if side != "A" && side != "B" {
+		return "", errInvalidSide
+	}
+	return side, nil
+}
+
+// newLiveState returns the state of a match before the first rally.
+func newLiveState(matchID uuid.UUID, bestOf int, firstServer string) *LiveState {
+	s := &LiveState{
+		MatchID:    matchID,
+		BestOf:     bestOf,
+		Sets:       []SetScore{{}},
+		CurrentSet: 1,
+		Server:     firstServer,
+	}
+	s.updateServiceCourt()
+	return s
+}
+
+// applyRally scores one rally. The rally winner always serves next, which also covers the
+// start of a new game: the winner of the previous game serves first.
+func (s *LiveState) applyRally(winner string) error {
+	if s.Winner != "" {
+		return errMatchFinished
+	}
+
+	cur := &s.Sets[len(s.Sets)-1]
+	if winner == "A" {
+		cur.A++
+	} else {
+		cur.B++
+	}
+	s.Rallies++
+	s.Server = winner
+
+	if gw := service.GameWinner(cur.A, cur.B); gw != "" {
+		cur.Winner = gw
+		if gw == "A" {
+			s.SetsWonA++
+		} else {
+			s.SetsWonB++
+		}
+
+		needed := s.BestOf/2 + 1
+		if s.SetsWonA == needed {
+			s.Winner = "A"
+		} else if s.SetsWonB == needed {
+			s.Winner = "B"
+		} else {
+			s.Sets = append(s.Sets, SetScore{})
+			s.CurrentSet++
+		}
+	}
+
+	s.updateServiceCourt()
+	return nil
+}
+
+func (s *LiveState) updateServiceCourt() {
+	cur := s.Sets[len(s.Sets)-1]
+	score := cur.A
+	if s.Server == "B" {
+		score = cur.B
+	}
+	if score%2 == 0 {
+		s.ServiceCourt = "right"
+	} else {
+		s.ServiceCourt = "left"
+	}
+}
+
+// replayRallies rebuilds the live state from stored events.
+func replayRallies(match *models.Match, events []models.RallyEvent) (*LiveState, error) {
+	firstServer := "A"
+	if len(events) > 0 {
+		firstServer = events[0].Server
+	}
+	state := newLiveState(match.ID, service.BestOf(match.Label), firstServer)
+	for _, e := range events {
+		if err := state.applyRally(e.Winner); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// Summary returns the Score and SetsDetail strings in the same format the score modal submits,
+// e.g. "2-1" and "21-19, 15-21, 21-17".
+func (s *LiveState) Summary() (score, setsDetail string) {
+	parts := make([]string, 0, len(s.Sets))
+	for _, set := range s.Sets {
+		if set.Winner == "" {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d-%d", set.A, set.B))
+	}
+	return fmt.Sprintf("%d-%d", s.SetsWonA, s.SetsWonB), strings.Join(parts, ", ")
+}
+
+type RecordRallyRequest struct {
+	Winner      string `json:"winner" binding:"required"` // "A" or "B"
+	FirstServer string `json:"first_server"`             // Only used for the first rally, defaults to "A"
+}
+
+// loadLiveState reads the match and its rallies. With lock set (inside a transaction) the match
+// row is locked so that concurrent submissions for the same match are serialised. SQLite has
+// no row locks; its transactions take the database write lock up front instead.
+func loadLiveState(ctx context.Context, conn bun.IDB, matchID string, lock bool) (*models.Match, []models.RallyEvent, *LiveState, error) {
+	var match models.Match
+	q := conn.NewSelect().Model(&match).Where("id = ?", matchID)
+	if lock && !db.IsSQLite(conn) {
+		q = q.For("UPDATE")
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var events []models.RallyEvent
+	if err := conn.NewSelect().Model(&events).Where("match_id = ?", match.ID).Order("seq ASC").Scan(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+
+	state, err := replayRallies(&match, events)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return &match, events, state, nil
+}
+
+// GetLiveScore returns the rally-by-rally state of a match
+// GET /api/matches/:id/live
+func (h *Handler) GetLiveScore(c *gin.Context) {
+	_, _, state, err := loadLiveState(c.Request.Context(), h.DB, c.Param("id"), false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// RecordRally appends one rally and finalises the match once a side has won it
+// POST /api/matches/:id/rallies
+func (h *Handler) RecordRally(c *gin.Context) {
+	var req RecordRallyRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	winner, err := normalizeSide(req.Winner)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	firstServer := "A"
+	if req.FirstServer != "" {
+		if firstServer, err = normalizeSide(req.FirstServer); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if !h.requireMatchPhase(c, c.Param("id"), service.OpPlay) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var match *models.Match
+	var state *LiveState
+
+	err = h.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var events []models.RallyEvent
+		var err error
+		match, events, state, err = loadLiveState(ctx, tx, c.Param("id"), true)
+		if err != nil {
+			return err
+		}
+
+		if match.WinnerID != uuid.Nil {
+			return errMatchFinished
+		}
+		if match.TeamAID == uuid.Nil || match.TeamBID == uuid.Nil {
+			return service.ValidationError("both teams must be assigned before scoring")
+		}
+
+		if len(events) == 0 {
+			state = newLiveState(match.ID, service.BestOf(match.Label), firstServer)
+		}
+
+		event := &models.RallyEvent{
+			MatchID: match.ID,
+			Seq:     len(events) + 1,
+			Server:  state.Server,
+			Winner:  winner,
+		}
+		if err := state.applyRally(winner); err != nil {
+			return err
+		}
+		if _, err := tx.NewInsert().Model(event).Exec(ctx); err != nil {
+			return err
+		}
+
+		// Keep the stored score in sync so the bracket shows progress while the match is live
+		match.Score, match.SetsDetail = state.Summary()
+		if state.Winner == "A" {
+			match.WinnerID = match.TeamAID
+		} else if state.Winner == "B" {
+			match.WinnerID = match.TeamBID
+		}
+		if _, err := tx.NewUpdate().Model(match).Column("winner_id", "score", "sets_detail").WherePK().Exec(ctx); err != nil {
+			return err
+		}
+
+		// The deciding rally and the routing of its result commit together
+		if match.WinnerID == uuid.Nil {
+			return nil
+		}
+		return h.withTx(tx).Service.PropagateResult(ctx, match, match.WinnerID)
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+		case errors.Is(err, errMatchFinished):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			serviceError(c, err)
+		}
+		return
+	}
+
+	if match.WinnerID != uuid.Nil {
+		h.recordAudit(c, "RecordRally", "match", match.ID.String(), nil, match)
+		log.Printf("[Live] Match %s (%s) decided by rally %d, winner %s", match.ID, match.Label, state.Rallies, match.WinnerID)
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// UndoRally removes the last recorded rally of an unfinished match
+// DELETE /api/matches/:id/rallies/last
+func (h *Handler) UndoRally(c *gin.Context) {
+	if !h.requireMatchPhase(c, c.Param("id"), service.OpPlay) {
+		return
+	}
+	ctx := c.Request.Context()
+	var state *LiveState
+
+	err := h.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		match, events, _, err := loadLiveState(ctx, tx, c.Param("id"), true)
+		if err != nil {
+			return err
+		}
+
+		// Results already propagated into later matches cannot be taken back rally by rally
+		if match.WinnerID != uuid.Nil {
+			return errMatchFinished
+		}
+		if len(events) == 0 {
+			return service.ValidationError("no rallies to undo")
+		}
+
+		last := events[len(events)-1]
+		if _, err := tx.NewDelete().Model(&last).WherePK().Exec(ctx); err != nil {
+			return err
+		}
+
+		if state, err = replayRallies(match, events[:len(events)-1]); err != nil {
+			return err
+		}
+		match.Score, match.SetsDetail = state.Summary()
+		if len(events) == 1 {
+			match.Score = ""
+		}
+		_, err = tx.NewUpdate().Model(match).Column("score", "sets_detail").WherePK().Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+		case errors.Is(err, errMatchFinished):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			serviceError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+)
+
+// playRallies scores one rally per character of rallies ("A" or "B").
+func playRallies(t *testing.T, s *LiveState, rallies string) {
+	t.Helper()
+	for i, r := range rallies {
+		if err := s.applyRally(string(r)); err != nil {
+			t.Fatalf("rally %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestApplyRally(t *testing.T) {
+	tests := []struct {
+		name        string
+		bestOf      int
+		firstServer string
+		rallies     string
+		wantSets    []SetScore
+		wantServer  string
+		wantCourt   string
+		wantWinner  string
+	}{
+		{
+			name:        "first server serves from the right",
+			bestOf:      1,
+			firstServer: "B",
+			wantSets:    []SetScore{{}},
+			wantServer:  "B",
+			wantCourt:   "right",
+		},
+		{
+			name:        "receiver winning the rally takes the serve",
+			bestOf:      1,
+			firstServer: "A",
+			rallies:     "B",
+			wantSets:    []SetScore{{A: 0, B: 1}},
+			wantServer:  "B",
+			wantCourt:   "left",
+		},
+		{
+			name:        "server keeps the serve and switches court",
+			bestOf:      1,
+			firstServer: "A",
+			rallies:     "AA",
+			wantSets:    []SetScore{{A: 2, B: 0}},
+			wantServer:  "A",
+			wantCourt:   "right",
+		},
+		{
+			name:        "21 points win a game",
+			bestOf:      1,
+			firstServer: "A",
+			rallies:     strings.Repeat("A", 21),
+			wantSets:    []SetScore{{A: 21, B: 0, Winner: "A"}},
+			wantServer:  "A",
+			wantCourt:   "left",
+			wantWinner:  "A",
+		},
+		{
+			name:        "deuce at 20-all needs two clear points",
+			bestOf:      1,
+			firstServer: "A",
+			rallies:     strings.Repeat("AB", 20) + "A",
+			wantSets:    []SetScore{{A: 21, B: 20}},
+			wantServer:  "A",
+			wantCourt:   "left",
+		},
+		{
+			name:        "two clear points after deuce",
+			bestOf:      1,
+			firstServer: "A",
+			rallies:     strings.Repeat("AB", 20) + "BB",
+			wantSets:    []SetScore{{A: 20, B: 22, Winner: "B"}},
+			wantServer:  "B",
+			wantCourt:   "right",
+			wantWinner:  "B",
+		},
+		{
+			name:        "29-all is still open",
+			bestOf:      1,
+			firstServer: "A",
+			rallies:     strings.Repeat("AB", 29),
+			wantSets:    []SetScore{{A: 29, B: 29}},
+			wantServer:  "B",
+			wantCourt:   "left",
+		},
+		{
+			name:        "30th point wins at 30-29",
+			bestOf:      1,
+			firstServer: "A",
+			rallies:     strings.Repeat("AB", 29) + "A",
+			wantSets:    []SetScore{{A: 30, B: 29, Winner: "A"}},
+			wantServer:  "A",
+			wantCourt:   "right",
+			wantWinner:  "A",
+		},
+		{
+			name:        "game winner serves first in the next game",
+			bestOf:      3,
+			firstServer: "A",
+			rallies:     strings.Repeat("B", 21),
+			wantSets:    []SetScore{{A: 0, B: 21, Winner: "B"}, {}},
+			wantServer:  "B",
+			wantCourt:   "right",
+		},
+		{
+			name:        "best of three goes to a third game",
+			bestOf:      3,
+			firstServer: "A",
+			rallies:     strings.Repeat("A", 21) + strings.Repeat("B", 21) + "A",
+			wantSets:    []SetScore{{A: 21, B: 0, Winner: "A"}, {A: 0, B: 21, Winner: "B"}, {A: 1, B: 0}},
+			wantServer:  "A",
+			wantCourt:   "left",
+		},
+		{
+			name:        "best of three ends after two games won",
+			bestOf:      3,
+			firstServer: "B",
+			rallies:     strings.Repeat("A", 21) + strings.Repeat("B", 21) + strings.Repeat("A", 21),
+			wantSets:    []SetScore{{A: 21, B: 0, Winner: "A"}, {A: 0, B: 21, Winner: "B"}, {A: 21, B: 0, Winner: "A"}},
+			wantServer:  "A",
+			wantCourt:   "left",
+			wantWinner:  "A",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newLiveState(uuid.New(), tt.bestOf, tt.firstServer)
+			playRallies(t, s, tt.rallies)
+
+			if !reflect.DeepEqual(s.Sets, tt.wantSets) {
+				t.Errorf("sets = %+v, want %+v", s.Sets, tt.wantSets)
+			}
+			if s.CurrentSet != len(tt.wantSets) {
+				t.Errorf("current set = %d, want %d", s.CurrentSet, len(tt.wantSets))
+			}
+			if s.Server != tt.wantServer || s.ServiceCourt != tt.wantCourt {
+				t.Errorf("server = %s from the %s, want %s from the %s", s.Server, s.ServiceCourt, tt.wantServer, tt.wantCourt)
+			}
+			if s.Winner != tt.wantWinner {
+				t.Errorf("winner = %q, want %q", s.Winner, tt.wantWinner)
+			}
+			if s.Rallies != len(tt.rallies) {
+				t.Errorf("rallies = %d, want %d", s.Rallies, len(tt.rallies))
+			}
+		})
+	}
+}
+
+func TestApplyRallyAfterMatchEnd(t *testing.T) {
+	s := newLiveState(uuid.New(), 1, "A")
+	playRallies(t, s, strings.Repeat("A", 21))
+
+	if err := s.applyRally("B"); !errors.Is(err, errMatchFinished) {
+		t.Fatalf("rally after the match = %v, want errMatchFinished", err)
+	}
+	if s.Sets[0].B != 0 || s.Rallies != 21 {
+		t.Errorf("a rejected rally changed the score: %+v after %d rallies", s.Sets, s.Rallies)
+	}
+}
+
+func TestLiveStateSummary(t *testing.T) {
+	s := newLiveState(uuid.New(), 3, "A")
+	playRallies(t, s, strings.Repeat("AB", 20)+"AA"+strings.Repeat("B", 21)+"A")
+
+	score, setsDetail := s.Summary()
+	if score != "1-1" || setsDetail != "22-20, 0-21" {
+		t.Errorf("summary = %q %q, want \"1-1\" \"22-20, 0-21\"", score, setsDetail)
+	}
+}
+
+func TestReplayRallies(t *testing.T) {
+	match := &models.Match{ID: uuid.New(), Label: "Final"}
+	// The first event records who served first; each rally winner serves the next one
+	events := []models.RallyEvent{{Winner: "B", Server: "A"}}
+	for i := 0; i < 20; i++ {
+		events = append(events, models.RallyEvent{Winner: "B", Server: "B"})
+	}
+	events = append(events, models.RallyEvent{Winner: "A", Server: "B"})
+
+	s, err := replayRallies(match, events)
+	if err != nil {
+		t.Fatalf("replayRallies: %v", err)
+	}
+	if s.BestOf != 3 || s.SetsWonB != 1 || s.CurrentSet != 2 {
+		t.Errorf("state = best of %d, games won %d-%d, game %d; want best of 3, 0-1, game 2", s.BestOf, s.SetsWonA, s.SetsWonB, s.CurrentSet)
+	}
+	if s.Server != "A" || s.Sets[1] != (SetScore{A: 1}) {
+		t.Errorf("second game = %+v served by %s, want 1-0 served by A", s.Sets[1], s.Server)
+	}
+}
@@ -1,38 +1,117 @@
 package api
 
 import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/uptrace/bun"
 	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
 )
 
-// Webhook for Google Form
-// Expected format from Google Script
-// Webhook for Google Form
-// Updated format: { "name": "...", "group": "...", "categories": ["..."], "available_dates": ["..."] }
-type GoogleFormRequest struct {
-	Name           string   `json:"name"`
-	Group          string   `json:"group"` // Maps to Pool
-	Categories     []string `json:"categories"`
-	AvailableDates []string `json:"available_dates"`
-	Gender         string   `json:"gender"`
-	Source         string   `json:"source"`
-	Status         string   `json:"status"`
-}
-
+// HandleFormWebhook records the raw submission, then ingests it. Re-deliveries with the same
+// idempotency key (X-Idempotency-Key header, or a hash of the body) return the earlier result.
 func (h *Handler) HandleFormWebhook(c *gin.Context) {
-	var req GoogleFormRequest
-	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read body"})
+		return
+	}
+
+	source := c.GetString("webhook_source")
+	key := c.GetHeader("X-Idempotency-Key")
+	if key == "" {
+		sum := sha256.Sum256(body)
+		key = hex.EncodeToString(sum[:])
+	}
+	key = source + ":" + key
+
+	ctx := c.Request.Context()
+
+	var delivery models.WebhookDelivery
+	err = h.DB.NewSelect().Model(&delivery).Where("idempotency_key = ?", key).Scan(ctx)
+	switch {
+	case err == nil && delivery.Status == deliveryProcessed:
+		c.JSON(http.StatusOK, gin.H{"status": "success", "id": delivery.ParticipantID, "duplicate": true})
+		return
+	case err == nil:
+		// A previous attempt failed; process the new copy of the payload
+		delivery.Payload = string(body)
+	case errors.Is(err, sql.ErrNoRows):
+		delivery = models.WebhookDelivery{Source: source, IdempotencyKey: key, Payload: string(body), Status: deliveryFailed}
+		if _, err := h.DB.NewInsert().Model(&delivery).Returning("*").Exec(ctx); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Auto-generate pseudo-email removed. Usage Name as unique key.
-	
+	participant, procErr := h.processDelivery(ctx, &delivery)
+	if procErr != nil {
+		log.Printf("[Webhook] Delivery %s from %s failed: %v", delivery.ID, source, procErr)
+		if errors.Is(procErr, errInvalidPayload) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": procErr.Error(), "delivery_id": delivery.ID})
+			return
+		}
+		if errors.Is(procErr, errIdentityConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": procErr.Error(), "delivery_id": delivery.ID})
+			return
+		}
+		var perr *service.PhaseError
+		if errors.As(procErr, &perr) {
+			c.JSON(http.StatusConflict, gin.H{"error": procErr.Error(), "delivery_id": delivery.ID})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": procErr.Error(), "delivery_id": delivery.ID})
+		return
+	}
+	// Registrations are not audited, so recordAudit does not drop the stats for new or renamed players
+	h.stats.invalidate()
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "id": participant.ID})
+}
+
+var errInvalidPayload = errors.New("invalid JSON payload")
+
+// ingestFormPayload maps a form payload onto a participant using the source's field mapping
+// and upserts it by name.
+func (h *Handler) ingestFormPayload(ctx context.Context, source string, body []byte) (*models.Participant, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidPayload, err)
+	}
+
+	mapping, err := h.formMappingFor(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	req := applyFormMapping(mapping, payload)
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required (mapped from %q)", errInvalidPayload, mapping.Fields["name"])
+	}
+	// Kept as a failed delivery, so it can be replayed if registration reopens
+	if err := h.Service.CheckPhase(ctx, DefaultTournamentID, service.OpRegister); err != nil {
+		return nil, err
+	}
+	if req.Source == "" {
+		req.Source = source
+	}
+
 	participant := &models.Participant{
 		Name:           req.Name,
-		Pool:           req.Group, // Google Form "group" -> DB "pool"
+		ExternalID:     req.ExternalID,
+		Pool:           req.Pool,
 		Categories:     req.Categories,
 		AvailableDates: req.AvailableDates,
 		Gender:         req.Gender,
@@ -40,23 +119,81 @@ func (h *Handler) HandleFormWebhook(c *gin.Context) {
 		Status:         req.Status,
 	}
 
-	// Upsert: On conflict name, update pool/categories/available_dates
-	_, err := h.DB.NewInsert().Model(participant).
-		On("CONFLICT (name) DO UPDATE").
-		Set("pool = EXCLUDED.pool").
-		Set("categories = EXCLUDED.categories").
-		Set("available_dates = EXCLUDED.available_dates").
-		Set("gender = EXCLUDED.gender").
-		Set("source = EXCLUDED.source").
-		Set("status = EXCLUDED.status").
-		Exec(c.Request.Context())
+	if err := h.upsertParticipant(ctx, participant, registrationColumns); err != nil {
+		return nil, err
+	}
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+	h.warnPossibleDuplicates(ctx, participant)
+	return participant, nil
+}
+
+// errIdentityConflict is returned when a registration uses the name of a participant who has
+// a different external ID: two people sharing a name, who must not be merged.
+var errIdentityConflict = errors.New("name is already registered to a participant with a different external ID")
+
+// identityConflict reports whether existing and p are different people who share a name.
+func identityConflict(existing, p *models.Participant) bool {
+	return existing.ExternalID != "" && p.ExternalID != "" && existing.ExternalID != p.ExternalID
+}
+
+// registrationColumns are the fields a registration form sets on a participant it already
+// knows. Status is not among them, so a resubmitted form does not reactivate a withdrawn player.
+var registrationColumns = []string{"name", "pool", "categories", "available_dates", "gender", "source"}
+
+// upsertParticipant stores a registration: matched by external ID first (survives renames),
+// then by exact name. Only the given columns are changed on an existing participant; an
+// external ID is filled in if missing.
+func (h *Handler) upsertParticipant(ctx context.Context, participant *models.Participant, columns []string) error {
+	// A known external ID identifies the person even if they changed how they spell their name
+	if participant.ExternalID != "" {
+		var existing models.Participant
+		err := h.DB.NewSelect().Model(&existing).Where("external_id = ?", participant.ExternalID).Scan(ctx)
+		if err == nil {
+			participant.ID = existing.ID
+			participant.CreatedAt = existing.CreatedAt
+			_, err = h.DB.NewUpdate().Model(participant).
+				Column(columns...).
+				WherePK().
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+			if existing.Name != participant.Name {
+				if err := refreshTeamNames(ctx, h.DB, participant.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "success", "id": participant.ID})
+	// Upsert: On conflict name, update the given columns. An external ID is only filled in,
+	// never replaced: a different one means a different person
+	q := h.DB.NewInsert().Model(participant).
+		On("CONFLICT (name) DO UPDATE").
+		Set("external_id = COALESCE(p.external_id, EXCLUDED.external_id)")
+	for _, col := range columns {
+		if col != "name" {
+			q.Set("? = EXCLUDED.?", bun.Ident(col), bun.Ident(col))
+		}
+	}
+	res, err := q.
+		Where("p.external_id IS NULL OR EXCLUDED.external_id IS NULL OR p.external_id = EXCLUDED.external_id").
+		Returning("id").
+		Exec(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %q", errIdentityConflict, participant.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %q", errIdentityConflict, participant.Name)
+	}
+	return nil
 }
 
 func (h *Handler) ListParticipants(c *gin.Context) {
@@ -77,3 +214,179 @@ func (h *Handler) ListParticipants(c *gin.Context) {
 
 	c.JSON(http.StatusOK, participants)
 }
+
+type CreateParticipantRequest struct {
+	Name           string   `json:"name" binding:"required"`
+	ExternalID     string   `json:"external_id"`
+	Pool           string   `json:"pool" binding:"required"`
+	Gender         string   `json:"gender"`
+	Categories     []string `json:"categories"`
+	AvailableDates []string `json:"available_dates"`
+	Status         string   `json:"status"`
+}
+
+// UpdateParticipantRequest - only fields that are present are changed
+type UpdateParticipantRequest struct {
+	Name           *string   `json:"name"`
+	ExternalID     *string   `json:"external_id"`
+	Pool           *string   `json:"pool"`
+	Gender         *string   `json:"gender"`
+	Categories     *[]string `json:"categories"`
+	AvailableDates *[]string `json:"available_dates"`
+	Status         *string   `json:"status"`
+}
+
+// CreateParticipant - Manual registration by an admin
+// POST /api/admin/participants
+func (h *Handler) CreateParticipant(c *gin.Context) {
+	var req CreateParticipantRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.requirePhase(c, DefaultTournamentID, service.OpRegister) {
+		return
+	}
+
+	participant := &models.Participant{
+		Name:           strings.TrimSpace(req.Name),
+		ExternalID:     strings.ToLower(strings.TrimSpace(req.ExternalID)),
+		Pool:           req.Pool,
+		Gender:         req.Gender,
+		Categories:     req.Categories,
+		AvailableDates: req.AvailableDates,
+		Source:         "admin",
+		Status:         req.Status,
+	}
+
+	if _, err := h.DB.NewInsert().Model(participant).Returning("*").Exec(c.Request.Context()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to create participant: " + err.Error()})
+		return
+	}
+	h.recordAudit(c, "CreateParticipant", "participant", participant.ID.String(), nil, participant)
+
+	c.JSON(http.StatusCreated, participant)
+}
+
+// UpdateParticipant - Edit registration data; team names follow a rename
+// PUT /api/admin/participants/:id
+func (h *Handler) UpdateParticipant(c *gin.Context) {
+	var req UpdateParticipantRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.requirePhase(c, DefaultTournamentID, service.OpEditParticipant) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var participant models.Participant
+	if err := h.DB.NewSelect().Model(&participant).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Participant not found"})
+		return
+	}
+	before := participant
+
+	if req.Name != nil {
+		participant.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.ExternalID != nil {
+		participant.ExternalID = strings.ToLower(strings.TrimSpace(*req.ExternalID))
+	}
+	if req.Pool != nil {
+		participant.Pool = *req.Pool
+	}
+	if req.Gender != nil {
+		participant.Gender = *req.Gender
+	}
+	if req.Categories != nil {
+		participant.Categories = *req.Categories
+	}
+	if req.AvailableDates != nil {
+		participant.AvailableDates = *req.AvailableDates
+	}
+	if req.Status != nil {
+		participant.Status = *req.Status
+	}
+
+	if participant.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Name cannot be empty"})
+		return
+	}
+	// Withdrawing also awards the player's pending matches as walkovers, which a status edit would skip
+	if strings.EqualFold(participant.Status, models.ParticipantWithdrawn) && before.Status != models.ParticipantWithdrawn {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Use POST /api/admin/participants/:id/withdraw to withdraw a participant"})
+		return
+	}
+
+	// Teams are bound to a pool; moving a player who already has a team would break that invariant
+	if participant.Pool != before.Pool {
+		count, err := h.DB.NewSelect().Model((*models.Team)(nil)).
+			Where("player1_id = ? OR player2_id = ?", participant.ID, participant.ID).
+			Count(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if count > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change pool of a participant who is in a team"})
+			return
+		}
+	}
+
+	err := h.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(&participant).
+			Column("name", "external_id", "pool", "gender", "categories", "available_dates", "status").
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+		if participant.Name == before.Name {
+			return nil
+		}
+		return refreshTeamNames(ctx, tx, participant.ID)
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to update participant: " + err.Error()})
+		return
+	}
+	h.recordAudit(c, "UpdateParticipant", "participant", participant.ID.String(), before, participant)
+
+	c.JSON(http.StatusOK, participant)
+}
+
+// DeleteParticipant - Remove a registration that is not part of any team
+// DELETE /api/admin/participants/:id
+func (h *Handler) DeleteParticipant(c *gin.Context) {
+	if !h.requirePhase(c, DefaultTournamentID, service.OpRegister) {
+		return
+	}
+	ctx := c.Request.Context()
+
+	var participant models.Participant
+	if err := h.DB.NewSelect().Model(&participant).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Participant not found"})
+		return
+	}
+
+	count, err := h.DB.NewSelect().Model((*models.Team)(nil)).
+		Where("player1_id = ? OR player2_id = ?", participant.ID, participant.ID).
+		Count(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Participant is in a team; disband the team or withdraw the participant instead"})
+		return
+	}
+
+	if _, err := h.DB.NewDelete().Model(&participant).WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "DeleteParticipant", "participant", participant.ID.String(), participant, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Participant deleted"})
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+)
+
+// CategoryPlacements is the final ranking of one category of a tournament.
+type CategoryPlacements struct {
+	Category   string      `json:"category"`
+	Complete   bool        `json:"complete"` // every position, including the podium, is decided
+	Placements []Placement `json:"placements"`
+}
+
+// loadPlacements ranks every category of the tournament, or only the given one.
+func (h *Handler) loadPlacements(ctx context.Context, tournamentID uuid.UUID, category string) ([]CategoryPlacements, error) {
+	var groups []models.Group
+	q := h.DB.NewSelect().Model(&groups).Relation("Matches").
+		Where("tournament_id = ?", tournamentID).
+		Order("category ASC", "name ASC")
+	if category != "" {
+		q.Where("category = ?", category)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	var teams []models.Team
+	if err := h.DB.NewSelect().Model(&teams).Relation("Player1").Relation("Player2").Scan(ctx); err != nil {
+		return nil, err
+	}
+	teamByID := make(map[uuid.UUID]models.Team, len(teams))
+	for _, t := range teams {
+		teamByID[t.ID] = t
+	}
+
+	byCategory := make(map[string][]models.Group)
+	for _, g := range groups {
+		byCategory[g.Category] = append(byCategory[g.Category], g)
+	}
+	categories := make([]string, 0, len(byCategory))
+	for c := range byCategory {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+
+	result := make([]CategoryPlacements, 0, len(categories))
+	for _, c := range categories {
+		placements, complete := categoryPlacements(byCategory[c])
+		for i := range placements {
+			p := &placements[i]
+			t, ok := teamByID[p.TeamID]
+			if !ok {
+				continue
+			}
+			p.TeamName = t.Name
+			for _, player := range []*models.Participant{t.Player1, t.Player2} {
+				if player != nil {
+					p.Players = append(p.Players, player.Name)
+				}
+			}
+		}
+		result = append(result, CategoryPlacements{Category: c, Complete: complete, Placements: placements})
+	}
+	return result, nil
+}
+
+// GetPlacements returns the final ranking per category, podium first
+// GET /api/placements?category=MensDoubles&tournament_id=<uuid>
+func (h *Handler) GetPlacements(c *gin.Context) {
+	tournamentID := DefaultTournamentID
+	if raw := c.Query("tournament_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament_id"})
+			return
+		}
+		tournamentID = id
+	}
+
+	placements, err := h.loadPlacements(c.Request.Context(), tournamentID, c.Query("category"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, placements)
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"context"
+	"embed"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+//go:embed templates/*.html
+var printTemplateFS embed.FS
+
+var printTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"seq": func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i + 1
+		}
+		return s
+	},
+}).ParseFS(printTemplateFS, "templates/*.html"))
+
+// maxGamePoints is the longest possible game (29-all, first to 30), i.e. the tally grid width.
+const maxGamePoints = 30
+
+// printPlaceholders names the empty slots of matches whose teams come from earlier results.
+var printPlaceholders = map[string][2]string{
+	"Winners": {"Winner M1", "Winner M2"},
+	"Losers":  {"Loser M1", "Loser M2"},
+	"Decider": {"Loser of Winners", "Winner of Losers"},
+	"SF1":     {"Mesoneer #1", "Lab #2"},
+	"SF2":     {"Lab #1", "Mesoneer #2"},
+	"Final":   {"Winner SF1", "Winner SF2"},
+	"Bronze":  {"Loser SF1", "Loser SF2"},
+}
+
+// printMatchOrder is the order matches are played, and printed, within a group.
+var printMatchOrder = map[string]int{
+	"M1": 1, "M2": 2, "Winners": 3, "Losers": 4, "Decider": 5,
+	"SF1": 1, "SF2": 2, "Bronze": 3, "Final": 4,
+}
+
+type printSide struct {
+	Name    string
+	Players string
+	TBD     bool
+}
+
+type printMatch struct {
+	ID         uuid.UUID
+	Label      string
+	Group      string
+	Pool       string
+	Category   string
+	Court      string
+	A, B       printSide
+	Winner     string
+	Score      string
+	SetsDetail string
+	BestOf     int
+	Points     int
+}
+
+type printGroup struct {
+	Group     models.Group
+	Matches   []printMatch
+	Standings []printStanding
+	Knockout  bool
+}
+
+// Match returns the match with the given label, or nil; used by the bracket template.
+func (g *printGroup) Match(label string) *printMatch {
+	for i := range g.Matches {
+		if g.Matches[i].Label == label {
+			return &g.Matches[i]
+		}
+	}
+	return nil
+}
+
+type printStanding struct {
+	Rank int
+	Team string
+}
+
+type printPage struct {
+	Title       string
+	GeneratedAt time.Time
+	Matches     []printMatch // score sheets
+	Group       *printGroup
+	Bracket     *printGroup
+}
+
+func sortPrintMatches(matches []*models.Match) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return printMatchOrder[matches[i].Label] < printMatchOrder[matches[j].Label]
+	})
+}
+
+// loadPrintTeams fetches the teams of the given matches with their players.
+func (h *Handler) loadPrintTeams(ctx context.Context, matches []*models.Match) (map[uuid.UUID]models.Team, error) {
+	var ids []uuid.UUID
+	for _, m := range matches {
+		for _, id := range []uuid.UUID{m.TeamAID, m.TeamBID} {
+			if id != uuid.Nil {
+				ids = append(ids, id)
+			}
+		}
+	}
+	teams := make(map[uuid.UUID]models.Team, len(ids))
+	if len(ids) == 0 {
+		return teams, nil
+	}
+
+	var list []models.Team
+	if err := h.DB.NewSelect().Model(&list).
+		Relation("Player1").
+		Relation("Player2").
+		Where("tm.id IN (?)", bun.In(ids)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	for _, t := range list {
+		teams[t.ID] = t
+	}
+	return teams, nil
+}
+
+func newPrintSide(teams map[uuid.UUID]models.Team, id uuid.UUID, placeholder string) printSide {
+	t, ok := teams[id]
+	if !ok {
+		return printSide{Name: placeholder, TBD: true}
+	}
+	side := printSide{Name: t.Name}
+	if t.Player1 != nil && t.Player2 != nil {
+		side.Players = t.Player1.Name + " / " + t.Player2.Name
+	}
+	return side
+}
+
+func newPrintMatch(g *models.Group, m *models.Match, teams map[uuid.UUID]models.Team) printMatch {
+	placeholders := printPlaceholders[m.Label]
+	pm := printMatch{
+		ID:         m.ID,
+		Label:      m.Label,
+		Group:      g.Name,
+		Pool:       g.Pool,
+		Category:   g.Category,
+		Court:      m.Court,
+		A:          newPrintSide(teams, m.TeamAID, placeholders[0]),
+		B:          newPrintSide(teams, m.TeamBID, placeholders[1]),
+		Score:      m.Score,
+		SetsDetail: m.SetsDetail,
+		BestOf:     service.BestOf(m.Label),
+		Points:     maxGamePoints,
+	}
+	if t, ok := teams[m.WinnerID]; ok {
+		pm.Winner = t.Name
+	}
+	return pm
+}
+
+// buildPrintGroup loads a group with its matches, teams and standings.
+func (h *Handler) buildPrintGroup(ctx context.Context, query func(q *bun.SelectQuery) *bun.SelectQuery) (*printGroup, error) {
+	var g models.Group
+	if err := query(h.DB.NewSelect().Model(&g).Relation("Matches")).Limit(1).Scan(ctx); err != nil {
+		return nil, err
+	}
+	sortPrintMatches(g.Matches)
+
+	teams, err := h.loadPrintTeams(ctx, g.Matches)
+	if err != nil {
+		return nil, err
+	}
+
+	pg := &printGroup{Group: g, Knockout: isKnockoutGroup(&g)}
+	for _, m := range g.Matches {
+		pg.Matches = append(pg.Matches, newPrintMatch(&g, m, teams))
+	}
+
+	standings := groupStandings(&g)
+	if pg.Knockout {
+		standings = knockoutPlacements(&g)
+	}
+	for _, s := range standings {
+		pg.Standings = append(pg.Standings, printStanding{Rank: s.Rank, Team: teams[s.TeamID].Name})
+	}
+	return pg, nil
+}
+
+func renderPrintPage(c *gin.Context, name string, page printPage) {
+	page.GeneratedAt = time.Now()
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := printTemplates.ExecuteTemplate(c.Writer, name, page); err != nil {
+		log.Printf("[Print] Rendering %s failed: %v", name, err)
+		c.Error(err)
+	}
+}
+
+// PrintScoreSheet renders a paper score sheet for one match
+// GET /api/admin/print/matches/:id
+func (h *Handler) PrintScoreSheet(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var m models.Match
+	if err := h.DB.NewSelect().Model(&m).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+		return
+	}
+	var g models.Group
+	if err := h.DB.NewSelect().Model(&g).Where("id = ?", m.GroupID).Scan(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	teams, err := h.loadPrintTeams(ctx, []*models.Match{&m})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	pm := newPrintMatch(&g, &m, teams)
+	renderPrintPage(c, "scoresheets.html", printPage{
+		Title:   g.Name + " " + m.Label,
+		Matches: []printMatch{pm},
+	})
+}
+
+// PrintGroupSheet renders the GSL overview of one group; ?scoresheets=true appends a score
+// sheet for each match, one per page.
+// GET /api/admin/print/groups/:id
+func (h *Handler) PrintGroupSheet(c *gin.Context) {
+	pg, err := h.buildPrintGroup(c.Request.Context(), func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("g.id = ?", c.Param("id"))
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Group not found"})
+		return
+	}
+
+	page := printPage{Title: pg.Group.Name, Group: pg}
+	if c.Query("scoresheets") == "true" {
+		page.Matches = pg.Matches
+	}
+	renderPrintPage(c, "group.html", page)
+}
+
+// PrintBracket renders the knockout tree of a category
+// GET /api/admin/print/bracket?category=MensDoubles
+func (h *Handler) PrintBracket(c *gin.Context) {
+	category := c.Query("category")
+	name := service.KnockoutGroupName(category)
+
+	pg, err := h.buildPrintGroup(c.Request.Context(), func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("g.name = ?", name).Where("g.category = ?", category)
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Knockout stage not found for category " + category})
+		return
+	}
+
+	renderPrintPage(c, "bracket.html", printPage{Title: "Bracket " + category, Bracket: pg})
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"badminton_tournament/backend/internal/models"
+)
+
+var (
+	rasterFontsOnce          sync.Once
+	rasterRegular, rasterBold *opentype.Font
+	rasterFontsErr           error
+)
+
+func loadRasterFonts() error {
+	rasterFontsOnce.Do(func() {
+		if rasterRegular, rasterFontsErr = opentype.Parse(goregular.TTF); rasterFontsErr != nil {
+			return
+		}
+		rasterBold, rasterFontsErr = opentype.Parse(gobold.TTF)
+	})
+	return rasterFontsErr
+}
+
+// parseHexColor turns "#rrggbb" into a color; anything else is black.
+func parseHexColor(s string) color.RGBA {
+	if len(s) != 7 || s[0] != '#' {
+		return color.RGBA{A: 0xff}
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return color.RGBA{A: 0xff}
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+// rasterizeCanvas paints the bracket display list. Connectors are axis-aligned, so lines are
+// drawn as thin rectangles and need no anti-aliasing.
+func rasterizeCanvas(cv *bracketCanvas, scale float64) (*image.RGBA, error) {
+	if err := loadRasterFonts(); err != nil {
+		return nil, err
+	}
+
+	px := func(v float64) int { return int(math.Round(v * scale)) }
+	img := image.NewRGBA(image.Rect(0, 0, px(cv.W), px(cv.H)))
+
+	fill := func(x0, y0, x1, y1 int, c color.RGBA) {
+		draw.Draw(img, image.Rect(x0, y0, x1, y1), &image.Uniform{c}, image.Point{}, draw.Src)
+	}
+	stroke := px(1)
+	if stroke < 1 {
+		stroke = 1
+	}
+
+	for _, r := range cv.Rects {
+		x0, y0, x1, y1 := px(r.X), px(r.Y), px(r.X+r.W), px(r.Y+r.H)
+		if r.Fill != "" {
+			fill(x0, y0, x1, y1, parseHexColor(r.Fill))
+		}
+		if r.Stroke != "" {
+			c := parseHexColor(r.Stroke)
+			fill(x0, y0, x1, y0+stroke, c)
+			fill(x0, y1-stroke, x1, y1, c)
+			fill(x0, y0, x0+stroke, y1, c)
+			fill(x1-stroke, y0, x1, y1, c)
+		}
+	}
+
+	for _, l := range cv.Lines {
+		c := parseHexColor(l.Stroke)
+		x0, y0, x1, y1 := px(math.Min(l.X1, l.X2)), px(math.Min(l.Y1, l.Y2)), px(math.Max(l.X1, l.X2)), px(math.Max(l.Y1, l.Y2))
+		horizontal := y0 == y1
+		length := x1 - x0
+		if !horizontal {
+			length = y1 - y0
+		}
+		dash, gap := length+1, 0
+		if l.Dashed {
+			dash, gap = px(4), px(3)
+		}
+		for off := 0; off <= length; off += dash + gap {
+			end := off + dash
+			if end > length {
+				end = length
+			}
+			if horizontal {
+				fill(x0+off, y0-stroke/2, x0+end+1, y0-stroke/2+stroke+1, c)
+			} else {
+				fill(x0-stroke/2, y0+off, x0-stroke/2+stroke+1, y0+end+1, c)
+			}
+		}
+	}
+
+	faces := make(map[[2]float64]font.Face)
+	defer func() {
+		for _, f := range faces {
+			f.Close()
+		}
+	}()
+	for _, t := range cv.Texts {
+		key := [2]float64{t.Size * scale, 0}
+		f := rasterRegular
+		if t.Bold {
+			key[1], f = 1, rasterBold
+		}
+		face, ok := faces[key]
+		if !ok {
+			var err error
+			if face, err = opentype.NewFace(f, &opentype.FaceOptions{Size: key[0], DPI: 72, Hinting: font.HintingFull}); err != nil {
+				return nil, err
+			}
+			faces[key] = face
+		}
+
+		text := fontSafeText(face, t.Text)
+		d := &font.Drawer{Dst: img, Src: &image.Uniform{parseHexColor(t.Color)}, Face: face}
+		x := fixed.I(px(t.X))
+		if t.End {
+			x -= d.MeasureString(text)
+		}
+		d.Dot = fixed.Point26_6{X: x, Y: fixed.I(px(t.Y))}
+		d.DrawString(text)
+	}
+
+	return img, nil
+}
+
+// fontSafeText replaces characters the Go fonts lack (most Vietnamese tone marks) with their
+// unaccented form so names stay readable instead of showing missing-glyph boxes.
+func fontSafeText(face font.Face, s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if _, ok := face.GlyphAdvance(r); ok {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(models.RemoveAccents(string(r)))
+	}
+	return b.String()
+}
+
+func encodePNG(w io.Writer, img image.Image) error {
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+	return enc.Encode(w, img)
+}
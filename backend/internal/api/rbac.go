@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultModelPath  = "auth/model.conf"
+	defaultPolicyPath = "auth/policy.csv"
+)
+
+// NewEnforcer loads the Casbin RBAC model and policy. Paths default to the files shipped in
+// backend/auth and can be overridden with CASBIN_MODEL and CASBIN_POLICY.
+func NewEnforcer() (*casbin.SyncedEnforcer, error) {
+	modelPath := os.Getenv("CASBIN_MODEL")
+	if modelPath == "" {
+		modelPath = defaultModelPath
+	}
+	policyPath := os.Getenv("CASBIN_POLICY")
+	if policyPath == "" {
+		policyPath = defaultPolicyPath
+	}
+
+	enforcer, err := casbin.NewSyncedEnforcer(modelPath, policyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load casbin policy (%s, %s): %w", modelPath, policyPath, err)
+	}
+	return enforcer, nil
+}
+
+// roleFromContext returns the role claim of the authenticated request.
+func roleFromContext(c *gin.Context) string {
+	if claims := claimsFromContext(c); claims != nil {
+		if role, ok := claims["role"].(string); ok {
+			return role
+		}
+	}
+	return ""
+}
+
+// authorize checks the request against the Casbin policy using the JWT role as subject.
+func (h *Handler) authorize(c *gin.Context) bool {
+	role := roleFromContext(c)
+	if role == "" {
+		return false
+	}
+
+	allowed, err := h.Enforcer.Enforce(role, c.Request.URL.Path, c.Request.Method)
+	if err != nil {
+		log.Printf("[RBAC] ERROR: enforce %s %s %s: %v", role, c.Request.Method, c.Request.URL.Path, err)
+		return false
+	}
+	return allowed
+}
+
+// ReloadPolicies re-reads the policy file so role changes apply without a restart
+// POST /api/admin/policies/reload
+func (h *Handler) ReloadPolicies(c *gin.Context) {
+	if err := h.Enforcer.LoadPolicy(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload policies: " + err.Error()})
+		return
+	}
+
+	log.Printf("[RBAC] Policies reloaded by %s", actorFromContext(c))
+	c.JSON(http.StatusOK, gin.H{"status": "reloaded"})
+}
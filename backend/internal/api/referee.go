@@ -0,0 +1,213 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+const (
+	defaultRefereeTokenTTL = 8 * time.Hour // One tournament day
+	maxRefereeTokenTTL     = 24 * time.Hour
+)
+
+type CreateRefereeTokenRequest struct {
+	Label      string    `json:"label"`
+	MatchID    uuid.UUID `json:"match_id"`
+	Court      string    `json:"court"`
+	TTLMinutes int       `json:"ttl_minutes"`
+}
+
+type SetMatchCourtRequest struct {
+	Court string `json:"court"`
+}
+
+// CreateRefereeToken mints a token that can only record results for one match or one court
+// POST /api/admin/referee-tokens
+func (h *Handler) CreateRefereeToken(c *gin.Context) {
+	var req CreateRefereeTokenRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.Court = strings.TrimSpace(req.Court)
+	if (req.MatchID == uuid.Nil) == (req.Court == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Exactly one of match_id or court is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if req.MatchID != uuid.Nil {
+		exists, err := h.DB.NewSelect().Model((*models.Match)(nil)).Where("id = ?", req.MatchID).Exists(ctx)
+		if err != nil || !exists {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+			return
+		}
+	}
+
+	ttl := defaultRefereeTokenTTL
+	if req.TTLMinutes > 0 {
+		ttl = time.Duration(req.TTLMinutes) * time.Minute
+	}
+	if ttl > maxRefereeTokenTTL {
+		ttl = maxRefereeTokenTTL
+	}
+
+	rt := &models.RefereeToken{
+		Label:     req.Label,
+		MatchID:   req.MatchID,
+		Court:     req.Court,
+		CreatedBy: actorFromContext(c),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if _, err := h.DB.NewInsert().Model(rt).Returning("*").Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	subject := "referee"
+	if rt.Label != "" {
+		subject = "referee:" + rt.Label
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  subject,
+		"role": "referee",
+		"rtid": rt.ID.String(),
+		"exp":  rt.ExpiresAt.Unix(),
+	})
+	secret, err := jwtSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	tokenString, err := token.SignedString(secret)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	h.recordAudit(c, "CreateRefereeToken", "referee_token", rt.ID.String(), nil, rt)
+
+	resp := gin.H{"token": tokenString, "referee_token": rt}
+	// Link for a QR code on the volunteer's phone
+	if frontendURL := os.Getenv("FRONTEND_URL"); frontendURL != "" {
+		resp["link"] = strings.TrimRight(frontendURL, "/") + "/referee?token=" + tokenString
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListRefereeTokens returns all referee tokens, newest first
+// GET /api/admin/referee-tokens
+func (h *Handler) ListRefereeTokens(c *gin.Context) {
+	var tokens []models.RefereeToken
+	if err := h.DB.NewSelect().Model(&tokens).Order("created_at DESC").Scan(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// RevokeRefereeToken invalidates a referee token before it expires
+// DELETE /api/admin/referee-tokens/:id
+func (h *Handler) RevokeRefereeToken(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var rt models.RefereeToken
+	if err := h.DB.NewSelect().Model(&rt).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Referee token not found"})
+		return
+	}
+	if !rt.RevokedAt.IsZero() {
+		c.JSON(http.StatusOK, rt)
+		return
+	}
+
+	before := rt
+	rt.RevokedAt = time.Now()
+	if _, err := h.DB.NewUpdate().Model(&rt).Column("revoked_at").WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "RevokeRefereeToken", "referee_token", rt.ID.String(), before, rt)
+
+	c.JSON(http.StatusOK, rt)
+}
+
+// SetMatchCourt assigns a match to a physical court
+// PUT /api/matches/:id/court
+func (h *Handler) SetMatchCourt(c *gin.Context) {
+	var req SetMatchCourtRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.requireMatchPhase(c, c.Param("id"), service.OpPlay) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var match models.Match
+	if err := h.DB.NewSelect().Model(&match).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+		return
+	}
+
+	before := match
+	match.Court = strings.TrimSpace(req.Court)
+	if _, err := h.DB.NewUpdate().Model(&match).Column("court").WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "SetMatchCourt", "match", match.ID.String(), before, match)
+
+	c.JSON(http.StatusOK, match)
+}
+
+// checkRefereeScope validates a match-scoped referee token: it must exist, be neither revoked
+// nor expired, and the :id of the requested match must be inside its scope.
+func (h *Handler) checkRefereeScope(c *gin.Context, tokenID string) error {
+	ctx := c.Request.Context()
+
+	var rt models.RefereeToken
+	if err := h.DB.NewSelect().Model(&rt).Where("id = ?", tokenID).Scan(ctx); err != nil {
+		return errors.New("referee token not found")
+	}
+	if !rt.RevokedAt.IsZero() {
+		return errors.New("referee token has been revoked")
+	}
+	if time.Now().After(rt.ExpiresAt) {
+		return errors.New("referee token has expired")
+	}
+
+	matchID := c.Param("id")
+	if matchID == "" {
+		return errors.New("referee token only permits recording match results")
+	}
+
+	if rt.MatchID != uuid.Nil {
+		if rt.MatchID.String() != matchID {
+			return fmt.Errorf("referee token is not valid for match %s", matchID)
+		}
+		return nil
+	}
+
+	var match models.Match
+	if err := h.DB.NewSelect().Model(&match).Where("id = ?", matchID).Scan(ctx); err != nil {
+		return errors.New("match not found")
+	}
+	if match.Court != rt.Court {
+		return fmt.Errorf("referee token is only valid on court %s", rt.Court)
+	}
+	return nil
+}
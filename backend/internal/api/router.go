@@ -1,16 +1,36 @@
 package api
 
 import (
+	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/repository"
+	"badminton_tournament/backend/internal/repository/postgres"
+	"badminton_tournament/backend/internal/service"
 )
 
 type Handler struct {
-	DB *bun.DB
+	DB       bun.IDB
+	Enforcer *casbin.SyncedEnforcer
+	// Store and Service run on DB; withTx rebinds them together with it.
+	Store   *repository.Store
+	Service *service.Tournament
+
+	stats *statsCache
+}
+
+func NewHandler(db bun.IDB, enforcer *casbin.SyncedEnforcer) *Handler {
+	store := postgres.NewStore(db)
+	return &Handler{DB: db, Enforcer: enforcer, Store: store, Service: service.New(store), stats: &statsCache{}}
 }
 
-func NewHandler(db *bun.DB) *Handler {
-	return &Handler{DB: db}
+// withTx returns a copy of the handler whose queries run inside tx, so multi-step operations
+// (propagation cascades) can be committed or rolled back as a whole.
+func (h *Handler) withTx(tx bun.Tx) *Handler {
+	th := NewHandler(tx, h.Enforcer)
+	th.Service.Shuffle = h.Service.Shuffle
+	th.stats = h.stats
+	return th
 }
 
 func (h *Handler) RegisterRoutes(r *gin.Engine) {
@@ -18,19 +38,37 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 	
 	// Auth
 	api.POST("/auth/login", h.Login)
-	api.POST("/webhooks/form", h.HandleFormWebhook)
+
+	// Signed form submissions (see docs/webhook_signing.md)
+	webhooks := api.Group("/")
+	webhooks.Use(h.WebhookSignatureMiddleware())
+	{
+		webhooks.POST("/webhooks/form", h.HandleFormWebhook)
+		webhooks.POST("/participants", h.HandleFormWebhook) // Endpoint for Google Form Script
+	}
 
 	// Public
 	api.GET("/participants", h.ListParticipants)
-	api.POST("/participants", h.HandleFormWebhook) // Endpoint for Google Form Script
 	api.GET("/teams", h.ListTeams)
+	api.GET("/tournaments/:id", h.GetTournament)
 	api.GET("/groups", h.ListGroups)
+	api.GET("/placements", h.GetPlacements)
+	api.GET("/stats/teams", h.ListTeamStats)
+	api.GET("/stats/teams/:id", h.GetTeamStats)
+	api.GET("/stats/participants", h.ListParticipantStats)
+	api.GET("/stats/participants/:id", h.GetParticipantStats)
+	api.GET("/head-to-head", h.GetHeadToHead)
+	api.GET("/season/leaderboard", h.GetSeasonLeaderboard)
+	api.GET("/hall-of-fame", h.GetHallOfFame)
 	api.GET("/matches/:id", h.GetMatch)
+	api.GET("/matches/:id/live", h.GetLiveScore)
 	api.GET("/public/rules", h.GetRules)
+	api.GET("/bracket.svg", h.GetBracketSVG)
+	api.GET("/bracket.png", h.GetBracketPNG)
 
 	// Admin
 	admin := api.Group("/")
-	admin.Use(AuthMiddleware())
+	admin.Use(h.AuthMiddleware())
 	{
 
 		admin.POST("/teams", h.CreateTeam)
@@ -40,7 +78,43 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 		admin.POST("/groups", h.CreateGroup)
 		admin.POST("/groups/auto-generate", h.AutoGenerateGroups)
 		admin.POST("/matches/:id", h.UpdateMatch)
+		admin.POST("/matches/:id/rallies", h.RecordRally)
+		admin.DELETE("/matches/:id/rallies/last", h.UndoRally)
 		admin.POST("/tournaments/knockout", h.GenerateKnockout)
+		admin.POST("/tournaments/:id/transition", h.TransitionTournament)
 		admin.PUT("/admin/rules", h.UpdateRules)
+		admin.GET("/admin/audit", h.ListAuditEvents)
+		admin.POST("/admin/policies/reload", h.ReloadPolicies)
+		admin.GET("/admin/users", h.ListUsers)
+		admin.POST("/admin/users", h.CreateUserAccount)
+		admin.PUT("/admin/users/:id/role", h.UpdateUserRole)
+		admin.DELETE("/admin/users/:id", h.DeleteUser)
+		admin.PUT("/auth/password", h.ChangePassword)
+		admin.PUT("/matches/:id/court", h.SetMatchCourt)
+		admin.GET("/admin/referee-tokens", h.ListRefereeTokens)
+		admin.POST("/admin/referee-tokens", h.CreateRefereeToken)
+		admin.DELETE("/admin/referee-tokens/:id", h.RevokeRefereeToken)
+		admin.GET("/admin/webhook-deliveries", h.ListWebhookDeliveries)
+		admin.GET("/admin/webhook-deliveries/:id", h.GetWebhookDelivery)
+		admin.PUT("/admin/webhook-deliveries/:id", h.UpdateWebhookDelivery)
+		admin.POST("/admin/webhook-deliveries/:id/replay", h.ReplayWebhookDelivery)
+		admin.GET("/admin/form-mappings", h.ListFormMappings)
+		admin.PUT("/admin/form-mappings/:source", h.UpsertFormMapping)
+		admin.DELETE("/admin/form-mappings/:source", h.DeleteFormMapping)
+		admin.GET("/admin/export/:dataset", h.Export)
+		admin.GET("/admin/integrity", h.CheckIntegrity)
+		admin.POST("/admin/integrity/repair", h.RepairIntegrity)
+		admin.GET("/admin/snapshot", h.ExportTournamentSnapshot)
+		admin.POST("/admin/snapshot/restore", h.RestoreTournamentSnapshot)
+		admin.GET("/admin/print/matches/:id", h.PrintScoreSheet)
+		admin.GET("/admin/print/groups/:id", h.PrintGroupSheet)
+		admin.GET("/admin/print/bracket", h.PrintBracket)
+		admin.GET("/admin/participants/duplicates", h.ListDuplicateParticipants)
+		admin.POST("/admin/participants/merge", h.MergeParticipants)
+		admin.POST("/admin/participants/import", h.ImportParticipants)
+		admin.POST("/admin/participants", h.CreateParticipant)
+		admin.PUT("/admin/participants/:id", h.UpdateParticipant)
+		admin.DELETE("/admin/participants/:id", h.DeleteParticipant)
+		admin.POST("/admin/participants/:id/withdraw", h.WithdrawParticipant)
 	}
 }
@@ -41,11 +41,14 @@ func (h *Handler) UpdateRules(c *gin.Context) {
 
 	// 1. Try to find existing rule
 	var rule models.Rule
+	var before *models.Rule
 	exists, _ := h.DB.NewSelect().Model(&rule).Exists(ctx)
 
 	if exists {
 		// Update existing (fetch ID first or just update all? Let's fetch last one)
 		h.DB.NewSelect().Model(&rule).Order("updated_at DESC").Limit(1).Scan(ctx)
+		previous := rule
+		before = &previous
 		rule.Content = req.Content
 		rule.UpdatedAt = time.Now()
 		_, err := h.DB.NewUpdate().Model(&rule).WherePK().Exec(ctx)
@@ -64,6 +67,7 @@ func (h *Handler) UpdateRules(c *gin.Context) {
 			return
 		}
 	}
+	h.recordAudit(c, "UpdateRules", "rule", rule.ID.String(), before, rule)
 
 	c.JSON(http.StatusOK, rule)
 }
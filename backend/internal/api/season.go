@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+// podiumPoints are the season points for the four knockout places; groupRankPoints for teams
+// out in the groups, by the position they finished in. Points follow the round a team reached:
+// 1st and 2nd of a group both won a qualifying place (the Winners and Decider matches), so
+// they score the same when there was no knockout slot left for them. 3rd went out in the
+// Decider and 4th in the Losers match.
+var (
+	podiumPoints    = map[int]int{1: 100, 2: 70, 3: 50, 4: 40}
+	groupRankPoints = map[int]int{1: 30, 2: 30, 3: 20, 4: 10}
+)
+
+func placementPoints(p Placement) int {
+	if p.GroupRank > 0 {
+		return groupRankPoints[p.GroupRank]
+	}
+	return podiumPoints[p.Rank]
+}
+
+// completedTournament is a finished tournament with its final placements.
+type completedTournament struct {
+	models.Tournament
+	categories []CategoryPlacements
+}
+
+// seasonOf returns the season a tournament counts toward: the calendar year it was created in.
+// Tournaments have no play date, so one created in December for a January event counts toward
+// the earlier year; create it in the year it is played.
+func seasonOf(t models.Tournament) int {
+	return t.CreatedAt.Year()
+}
+
+// loadCompletedTournaments returns the completed tournaments of a season (0 for all), newest
+// first, with their placements.
+func (h *Handler) loadCompletedTournaments(ctx context.Context, season int) ([]completedTournament, error) {
+	var tournaments []models.Tournament
+	if err := h.DB.NewSelect().Model(&tournaments).
+		Where("status = ?", service.PhaseCompleted).
+		Order("created_at DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	var result []completedTournament
+	for _, t := range tournaments {
+		if season != 0 && seasonOf(t) != season {
+			continue
+		}
+		categories, err := h.loadPlacements(ctx, t.ID, "")
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, completedTournament{Tournament: t, categories: categories})
+	}
+	return result, nil
+}
+
+// SeasonResult is what one tournament contributed to a leaderboard entry.
+type SeasonResult struct {
+	TournamentID uuid.UUID `json:"tournament_id"`
+	Tournament   string    `json:"tournament"`
+	Rank         int       `json:"rank"`
+	Points       int       `json:"points"`
+}
+
+// SeasonEntry is a player's season in one category. Players score with whichever partner
+// they had in each tournament.
+type SeasonEntry struct {
+	ParticipantID uuid.UUID      `json:"participant_id"`
+	Name          string         `json:"name"`
+	Category      string         `json:"category"`
+	Points        int            `json:"points"`
+	BestRank      int            `json:"best_rank"`
+	Results       []SeasonResult `json:"results"`
+}
+
+// GetSeasonLeaderboard ranks players by points earned in the completed tournaments of a season
+// (see seasonOf); it defaults to the current year
+// GET /api/season/leaderboard?season=2026&category=MensDoubles
+func (h *Handler) GetSeasonLeaderboard(c *gin.Context) {
+	season := time.Now().Year()
+	if raw := c.Query("season"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+			return
+		}
+		season = n
+	}
+	category := c.Query("category")
+	ctx := c.Request.Context()
+
+	tournaments, err := h.loadCompletedTournaments(ctx, season)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var teams []models.Team
+	if err := h.DB.NewSelect().Model(&teams).Relation("Player1").Relation("Player2").Scan(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	teamByID := make(map[uuid.UUID]models.Team, len(teams))
+	for _, t := range teams {
+		teamByID[t.ID] = t
+	}
+	leaderboard := seasonLeaderboard(tournaments, teamByID, category)
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":      season,
+		"tournaments": len(tournaments),
+		"leaderboard": leaderboard,
+	})
+}
+
+// seasonLeaderboard totals the placement points of every player per category, best first.
+// tournaments are newest first, as loadCompletedTournaments returns them.
+func seasonLeaderboard(tournaments []completedTournament, teamByID map[uuid.UUID]models.Team, category string) []*SeasonEntry {
+	type key struct {
+		participant uuid.UUID
+		category    string
+	}
+	entries := make(map[key]*SeasonEntry)
+	// Oldest first, so each player's results read in order
+	for i := len(tournaments) - 1; i >= 0; i-- {
+		t := tournaments[i]
+		for _, cp := range t.categories {
+			if category != "" && cp.Category != category {
+				continue
+			}
+			for _, p := range cp.Placements {
+				team, ok := teamByID[p.TeamID]
+				if !ok {
+					continue
+				}
+				for _, player := range []*models.Participant{team.Player1, team.Player2} {
+					if player == nil {
+						continue
+					}
+					k := key{player.ID, cp.Category}
+					e, ok := entries[k]
+					if !ok {
+						e = &SeasonEntry{ParticipantID: player.ID, Name: player.Name, Category: cp.Category}
+						entries[k] = e
+					}
+					points := placementPoints(p)
+					e.Points += points
+					if e.BestRank == 0 || p.Rank < e.BestRank {
+						e.BestRank = p.Rank
+					}
+					e.Results = append(e.Results, SeasonResult{TournamentID: t.ID, Tournament: t.Name, Rank: p.Rank, Points: points})
+				}
+			}
+		}
+	}
+
+	leaderboard := make([]*SeasonEntry, 0, len(entries))
+	for _, e := range entries {
+		leaderboard = append(leaderboard, e)
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		a, b := leaderboard[i], leaderboard[j]
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.BestRank != b.BestRank {
+			return a.BestRank < b.BestRank
+		}
+		return a.Name < b.Name
+	})
+	return leaderboard
+}
+
+// HallOfFameEntry is the champion and runner-up of one category of a completed tournament.
+type HallOfFameEntry struct {
+	TournamentID uuid.UUID  `json:"tournament_id"`
+	Tournament   string     `json:"tournament"`
+	Season       int        `json:"season"`
+	Category     string     `json:"category"`
+	Champion     *Placement `json:"champion"`
+	RunnerUp     *Placement `json:"runner_up,omitempty"`
+}
+
+// GetHallOfFame lists the champions of every completed tournament, newest first
+// GET /api/hall-of-fame?category=MensDoubles
+func (h *Handler) GetHallOfFame(c *gin.Context) {
+	tournaments, err := h.loadCompletedTournaments(c.Request.Context(), 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hallOfFame(tournaments, c.Query("category")))
+}
+
+// hallOfFame lists the decided champions and runners-up of each category, in tournament order.
+func hallOfFame(tournaments []completedTournament, category string) []HallOfFameEntry {
+	entries := []HallOfFameEntry{}
+	for _, t := range tournaments {
+		for _, cp := range t.categories {
+			if category != "" && cp.Category != category {
+				continue
+			}
+			entry := HallOfFameEntry{TournamentID: t.ID, Tournament: t.Name, Season: seasonOf(t.Tournament), Category: cp.Category}
+			for i := range cp.Placements {
+				p := &cp.Placements[i]
+				if p.TeamID == uuid.Nil {
+					continue
+				}
+				switch {
+				case p.Rank == 1 && p.GroupRank == 0:
+					entry.Champion = p
+				case p.Rank == 2 && p.GroupRank == 0:
+					entry.RunnerUp = p
+				}
+			}
+			if entry.Champion != nil {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+)
+
+func TestPlacementPoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		placement Placement
+		want      int
+	}{
+		{"champion", Placement{Rank: 1, Stage: "Final"}, 100},
+		{"runner-up", Placement{Rank: 2, Stage: "Final"}, 70},
+		{"bronze winner", Placement{Rank: 3, Stage: "Bronze"}, 50},
+		{"bronze loser", Placement{Rank: 4, Stage: "Bronze"}, 40},
+		{"group winner without a knockout slot", Placement{Rank: 5, GroupRank: 1}, 30},
+		{"group runner-up scores as much as the group winner", Placement{Rank: 6, GroupRank: 2}, 30},
+		{"out in the Decider", Placement{Rank: 7, Tied: true, GroupRank: 3}, 20},
+		{"out in the Losers match", Placement{Rank: 10, Tied: true, GroupRank: 4}, 10},
+		// Without a knockout stage the group winner is ranked 1st but did not win a final
+		{"group rank wins over overall rank", Placement{Rank: 1, GroupRank: 1}, 30},
+		{"below the podium without a group rank", Placement{Rank: 5}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := placementPoints(tt.placement); got != tt.want {
+				t.Errorf("placementPoints(%+v) = %d, want %d", tt.placement, got, tt.want)
+			}
+		})
+	}
+}
+
+type seasonFixture struct {
+	teams map[uuid.UUID]models.Team
+}
+
+// team registers a team of two new players in the fixture.
+func (f *seasonFixture) team(player1, player2 string) uuid.UUID {
+	team := models.Team{
+		ID:      uuid.New(),
+		Player1: &models.Participant{ID: uuid.New(), Name: player1},
+		Player2: &models.Participant{ID: uuid.New(), Name: player2},
+	}
+	f.teams[team.ID] = team
+	return team.ID
+}
+
+// partner returns a team of an existing player with someone new.
+func (f *seasonFixture) partner(teamID uuid.UUID, player2 string) uuid.UUID {
+	team := models.Team{
+		ID:      uuid.New(),
+		Player1: f.teams[teamID].Player1,
+		Player2: &models.Participant{ID: uuid.New(), Name: player2},
+	}
+	f.teams[team.ID] = team
+	return team.ID
+}
+
+func completed(name string, created time.Time, categories ...CategoryPlacements) completedTournament {
+	return completedTournament{
+		Tournament: models.Tournament{ID: uuid.New(), Name: name, CreatedAt: created},
+		categories: categories,
+	}
+}
+
+func TestSeasonLeaderboard(t *testing.T) {
+	f := &seasonFixture{teams: make(map[uuid.UUID]models.Team)}
+	anhBinh, chiDung, emGiang, hoaKhoa := f.team("Anh", "Binh"), f.team("Chi", "Dung"), f.team("Em", "Giang"), f.team("Hoa", "Khoa")
+	anhLan := f.partner(anhBinh, "Lan")
+	minhNga := f.team("Minh", "Nga")
+
+	spring := completed("Spring Cup", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		CategoryPlacements{Category: "MensDoubles", Placements: []Placement{
+			{Rank: 1, TeamID: anhBinh},
+			{Rank: 2, TeamID: chiDung},
+			{Rank: 3, TeamID: hoaKhoa},
+			{Rank: 4, TeamID: uuid.New()}, // deleted team
+			{Rank: 5, TeamID: emGiang, GroupRank: 1},
+		}},
+		CategoryPlacements{Category: "MixedDoubles", Placements: []Placement{{Rank: 1, TeamID: minhNga}}},
+	)
+	autumn := completed("Autumn Cup", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		CategoryPlacements{Category: "MensDoubles", Placements: []Placement{
+			{Rank: 1, TeamID: chiDung},
+			{Rank: 2, TeamID: anhLan},
+			{Rank: 3, TeamID: hoaKhoa},
+			{Rank: 5, TeamID: emGiang, GroupRank: 1},
+		}},
+	)
+	// Newest first, as loadCompletedTournaments returns them
+	tournaments := []completedTournament{autumn, spring}
+
+	type row struct {
+		name     string
+		category string
+		points   int
+		bestRank int
+	}
+	want := []row{
+		// Level on points and best finish, players go by name
+		{"Anh", "MensDoubles", 170, 1}, // 100 with Binh, 70 with Lan
+		{"Chi", "MensDoubles", 170, 1},
+		{"Dung", "MensDoubles", 170, 1},
+		// Level on points, the better finish ranks higher
+		{"Binh", "MensDoubles", 100, 1},
+		{"Hoa", "MensDoubles", 100, 3},
+		{"Khoa", "MensDoubles", 100, 3},
+		{"Lan", "MensDoubles", 70, 2},
+		{"Em", "MensDoubles", 60, 5},
+		{"Giang", "MensDoubles", 60, 5},
+		{"Minh", "MixedDoubles", 100, 1},
+		{"Nga", "MixedDoubles", 100, 1},
+	}
+
+	leaderboard := seasonLeaderboard(tournaments, f.teams, "")
+	if len(leaderboard) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(leaderboard), len(want))
+	}
+	for i, w := range want {
+		e := leaderboard[i]
+		if e.Name != w.name || e.Category != w.category || e.Points != w.points || e.BestRank != w.bestRank {
+			t.Errorf("entry %d = %s %s %d points best %d, want %s %s %d points best %d",
+				i, e.Name, e.Category, e.Points, e.BestRank, w.name, w.category, w.points, w.bestRank)
+		}
+	}
+
+	anh := leaderboard[0]
+	if len(anh.Results) != 2 || anh.Results[0].Tournament != "Spring Cup" || anh.Results[1].Tournament != "Autumn Cup" {
+		t.Errorf("Anh's results = %+v, want Spring Cup then Autumn Cup", anh.Results)
+	}
+
+	mixed := seasonLeaderboard(tournaments, f.teams, "MixedDoubles")
+	if len(mixed) != 2 || mixed[0].Name != "Minh" || mixed[1].Name != "Nga" {
+		t.Errorf("mixed doubles leaderboard = %+v, want Minh and Nga", mixed)
+	}
+}
+
+func TestHallOfFame(t *testing.T) {
+	champion, runnerUp := uuid.New(), uuid.New()
+	decided := completed("Spring Cup", time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC),
+		CategoryPlacements{Category: "MensDoubles", Placements: []Placement{
+			{Rank: 1, TeamID: champion, Stage: "Final"},
+			{Rank: 2, TeamID: runnerUp, Stage: "Final"},
+			{Rank: 3, TeamID: uuid.New(), Stage: "Bronze"},
+		}},
+		// No knockout stage: the group winner is ranked 1st but is no champion
+		CategoryPlacements{Category: "WomensDoubles", Placements: []Placement{
+			{Rank: 1, TeamID: uuid.New(), Stage: "Group rank 1", GroupRank: 1},
+			{Rank: 2, TeamID: uuid.New(), Stage: "Group rank 2", GroupRank: 2},
+		}},
+	)
+	// The Final was never played
+	undecided := completed("Autumn Cup", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		CategoryPlacements{Category: "MensDoubles", Placements: []Placement{
+			{Rank: 1, Stage: "Final"},
+			{Rank: 2, Stage: "Final"},
+		}},
+		CategoryPlacements{Category: "MixedDoubles", Placements: []Placement{
+			{Rank: 1, TeamID: uuid.New(), Stage: "Final"},
+		}},
+	)
+	tournaments := []completedTournament{undecided, decided}
+
+	entries := hallOfFame(tournaments, "")
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %+v", len(entries), entries)
+	}
+	if e := entries[0]; e.Tournament != "Autumn Cup" || e.Category != "MixedDoubles" || e.RunnerUp != nil {
+		t.Errorf("first entry = %+v, want the Autumn Cup mixed doubles champion without a runner-up", e)
+	}
+	e := entries[1]
+	if e.Tournament != "Spring Cup" || e.Category != "MensDoubles" || e.Season != 2025 {
+		t.Errorf("second entry = %s %s season %d, want Spring Cup MensDoubles season 2025", e.Tournament, e.Category, e.Season)
+	}
+	if e.Champion == nil || e.Champion.TeamID != champion || e.RunnerUp == nil || e.RunnerUp.TeamID != runnerUp {
+		t.Errorf("Spring Cup final = %+v v %+v, want %s v %s", e.Champion, e.RunnerUp, champion, runnerUp)
+	}
+
+	if entries := hallOfFame(tournaments, "WomensDoubles"); len(entries) != 0 {
+		t.Errorf("women's doubles without a knockout stage = %+v, want no entries", entries)
+	}
+}
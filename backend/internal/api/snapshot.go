@@ -0,0 +1,344 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/models"
+)
+
+const (
+	snapshotFormat  = "badminton-tournament-snapshot"
+	snapshotVersion = 1
+)
+
+// DefaultTournamentID is the tournament the frontend works with.
+var DefaultTournamentID = uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+var errSnapshotTargetNotEmpty = errors.New("target database already contains tournament data; restore needs an empty or freshly migrated database")
+
+// Snapshot is a self-contained JSON archive of one tournament. Bump snapshotVersion whenever
+// the shape changes and keep RestoreSnapshot able to read older versions.
+type Snapshot struct {
+	Format       string               `json:"format"`
+	Version      int                  `json:"version"`
+	ExportedAt   time.Time            `json:"exported_at"`
+	Tournament   models.Tournament    `json:"tournament"`
+	Participants []models.Participant `json:"participants"`
+	Teams        []models.Team        `json:"teams"`
+	Groups       []models.Group       `json:"groups"`
+	Matches      []models.Match       `json:"matches"`
+	Rules        []models.Rule        `json:"rules"`
+}
+
+// RestoreResult reports what RestoreSnapshot wrote and how IDs were remapped.
+type RestoreResult struct {
+	TournamentID uuid.UUID               `json:"tournament_id"`
+	Counts       map[string]int          `json:"counts"`
+	IDMap        map[uuid.UUID]uuid.UUID `json:"id_map"` // archive ID -> new ID
+	RulesSkipped bool                    `json:"rules_skipped,omitempty"`
+}
+
+// ExportSnapshot reads a tournament with its groups and matches, plus the participants,
+// teams and rules, which are shared by the whole installation.
+func ExportSnapshot(ctx context.Context, db bun.IDB, tournamentID uuid.UUID) (*Snapshot, error) {
+	s := &Snapshot{
+		Format:       snapshotFormat,
+		Version:      snapshotVersion,
+		ExportedAt:   time.Now().UTC(),
+		Participants: []models.Participant{},
+		Teams:        []models.Team{},
+		Groups:       []models.Group{},
+		Matches:      []models.Match{},
+		Rules:        []models.Rule{},
+	}
+
+	if err := db.NewSelect().Model(&s.Tournament).Where("id = ?", tournamentID).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("tournament %s: %w", tournamentID, err)
+	}
+	if err := db.NewSelect().Model(&s.Participants).Order("created_at ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	if err := db.NewSelect().Model(&s.Teams).Order("created_at ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	if err := db.NewSelect().Model(&s.Groups).Where("tournament_id = ?", tournamentID).Order("name ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	if len(s.Groups) > 0 {
+		groupIDs := make([]uuid.UUID, len(s.Groups))
+		for i, g := range s.Groups {
+			groupIDs[i] = g.ID
+		}
+		if err := db.NewSelect().Model(&s.Matches).Where("group_id IN (?)", bun.In(groupIDs)).Order("group_id ASC", "label ASC").Scan(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if err := db.NewSelect().Model(&s.Rules).Order("updated_at ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ReadSnapshot decodes an archive and checks that this version of the code can restore it.
+func ReadSnapshot(data []byte) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if s.Format != snapshotFormat {
+		return nil, fmt.Errorf("not a tournament snapshot (format %q)", s.Format)
+	}
+	if s.Version < 1 || s.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (this server reads up to %d)", s.Version, snapshotVersion)
+	}
+	return &s, nil
+}
+
+// remapper hands out fresh IDs and rejects references to entities missing from the archive.
+type remapper struct {
+	ids map[uuid.UUID]uuid.UUID
+}
+
+func (r *remapper) assign(old uuid.UUID) uuid.UUID {
+	id := uuid.New()
+	r.ids[old] = id
+	return id
+}
+
+func (r *remapper) ref(old uuid.UUID, what string) (uuid.UUID, error) {
+	if old == uuid.Nil {
+		return uuid.Nil, nil
+	}
+	id, ok := r.ids[old]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("snapshot references unknown %s %s", what, old)
+	}
+	return id, nil
+}
+
+// RestoreSnapshot writes an archive into an empty db under new IDs, with every link between
+// rows rewritten. Run it in a transaction: on error nothing should be kept.
+// The archived tournament takes over the target's tournament row with the same ID when that
+// row is still empty (e.g. the default tournament seeded by the migrations).
+func RestoreSnapshot(ctx context.Context, db bun.IDB, s *Snapshot) (*RestoreResult, error) {
+	for _, model := range []interface{}{(*models.Participant)(nil), (*models.Team)(nil), (*models.Group)(nil), (*models.Match)(nil)} {
+		count, err := db.NewSelect().Model(model).Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, errSnapshotTargetNotEmpty
+		}
+	}
+
+	r := &remapper{ids: make(map[uuid.UUID]uuid.UUID)}
+	result := &RestoreResult{Counts: make(map[string]int), IDMap: r.ids}
+
+	// Tournament
+	tournament := s.Tournament
+	var existing models.Tournament
+	err := db.NewSelect().Model(&existing).Where("id = ?", tournament.ID).Scan(ctx)
+	switch {
+	case err == nil:
+		r.ids[tournament.ID] = tournament.ID
+		if _, err := db.NewUpdate().Model(&tournament).Column("name", "status").WherePK().Exec(ctx); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		tournament.ID = r.assign(tournament.ID)
+		if _, err := db.NewInsert().Model(&tournament).Exec(ctx); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	result.TournamentID = tournament.ID
+	result.Counts["tournaments"] = 1
+
+	// IDs are assigned up front because matches link to matches restored after them
+	for _, p := range s.Participants {
+		r.assign(p.ID)
+	}
+	for _, t := range s.Teams {
+		r.assign(t.ID)
+	}
+	for _, g := range s.Groups {
+		r.assign(g.ID)
+	}
+	for _, m := range s.Matches {
+		r.assign(m.ID)
+	}
+
+	participants := make([]models.Participant, len(s.Participants))
+	for i, p := range s.Participants {
+		p.ID = r.ids[p.ID]
+		participants[i] = p
+	}
+
+	teams := make([]models.Team, len(s.Teams))
+	for i, t := range s.Teams {
+		t.Player1, t.Player2 = nil, nil
+		t.ID = r.ids[t.ID]
+		if t.Player1ID, err = r.ref(t.Player1ID, "participant"); err != nil {
+			return nil, err
+		}
+		if t.Player2ID, err = r.ref(t.Player2ID, "participant"); err != nil {
+			return nil, err
+		}
+		teams[i] = t
+	}
+
+	groups := make([]models.Group, len(s.Groups))
+	for i, g := range s.Groups {
+		g.Matches = nil
+		g.ID = r.ids[g.ID]
+		if g.TournamentID, err = r.ref(g.TournamentID, "tournament"); err != nil {
+			return nil, err
+		}
+		groups[i] = g
+	}
+
+	matches := make([]models.Match, len(s.Matches))
+	for i, m := range s.Matches {
+		m.TeamA, m.TeamB, m.Winner = nil, nil, nil
+		m.ID = r.ids[m.ID]
+		refs := []struct {
+			field *uuid.UUID
+			what  string
+		}{
+			{&m.GroupID, "group"},
+			{&m.TeamAID, "team"},
+			{&m.TeamBID, "team"},
+			{&m.WinnerID, "team"},
+			{&m.NextMatchWinID, "match"},
+			{&m.NextMatchLoseID, "match"},
+		}
+		for _, ref := range refs {
+			if *ref.field, err = r.ref(*ref.field, ref.what); err != nil {
+				return nil, err
+			}
+		}
+		matches[i] = m
+	}
+
+	if len(participants) > 0 {
+		if _, err := db.NewInsert().Model(&participants).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("participants: %w", err)
+		}
+	}
+	if len(teams) > 0 {
+		if _, err := db.NewInsert().Model(&teams).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("teams: %w", err)
+		}
+	}
+	if len(groups) > 0 {
+		if _, err := db.NewInsert().Model(&groups).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("groups: %w", err)
+		}
+	}
+	if len(matches) > 0 {
+		if _, err := db.NewInsert().Model(&matches).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("matches: %w", err)
+		}
+	}
+	result.Counts["participants"] = len(participants)
+	result.Counts["teams"] = len(teams)
+	result.Counts["groups"] = len(groups)
+	result.Counts["matches"] = len(matches)
+
+	// Rules are a singleton document; an existing one is kept rather than shadowed
+	ruleCount, err := db.NewSelect().Model((*models.Rule)(nil)).Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ruleCount > 0 {
+		result.RulesSkipped = len(s.Rules) > 0
+	} else if len(s.Rules) > 0 {
+		rules := make([]models.Rule, len(s.Rules))
+		for i, rule := range s.Rules {
+			rule.ID = r.assign(rule.ID)
+			rules[i] = rule
+		}
+		if _, err := db.NewInsert().Model(&rules).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("rules: %w", err)
+		}
+		result.Counts["rules"] = len(rules)
+	}
+
+	return result, nil
+}
+
+// ExportTournamentSnapshot downloads a tournament as a JSON archive
+// GET /api/admin/snapshot?tournament_id=<uuid>
+func (h *Handler) ExportTournamentSnapshot(c *gin.Context) {
+	tournamentID := DefaultTournamentID
+	if raw := c.Query("tournament_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament_id"})
+			return
+		}
+		tournamentID = id
+	}
+
+	s, err := ExportSnapshot(c.Request.Context(), h.DB, tournamentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("snapshot-%s.json", s.ExportedAt.Format("20060102-150405"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.JSON(http.StatusOK, s)
+}
+
+// RestoreTournamentSnapshot loads an archive produced by ExportTournamentSnapshot into an
+// empty database. The body is the archive itself.
+// POST /api/admin/snapshot/restore
+func (h *Handler) RestoreTournamentSnapshot(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read body"})
+		return
+	}
+	s, err := ReadSnapshot(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var result *RestoreResult
+	err = h.DB.RunInTx(c.Request.Context(), nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		result, err = RestoreSnapshot(ctx, tx, s)
+		return err
+	})
+	if errors.Is(err, errSnapshotTargetNotEmpty) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Restore rolled back: " + err.Error()})
+		return
+	}
+	h.recordAudit(c, "RestoreSnapshot", "tournament", result.TournamentID.String(), nil, gin.H{
+		"exported_at": s.ExportedAt,
+		"counts":      result.Counts,
+	})
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+)
+
+const knockoutPrefix = "KNOCKOUT"
+
+// Standing is a team's finishing position in a group or knockout stage. TeamID is uuid.Nil
+// while the match deciding that position has not been played.
+type Standing struct {
+	Rank   int       `json:"rank"`
+	TeamID uuid.UUID `json:"team_id,omitempty"`
+}
+
+func isKnockoutGroup(g *models.Group) bool {
+	return strings.HasPrefix(g.Name, knockoutPrefix)
+}
+
+// matchLoser returns the team that lost a decided match.
+func matchLoser(m *models.Match) uuid.UUID {
+	switch m.WinnerID {
+	case uuid.Nil:
+		return uuid.Nil
+	case m.TeamAID:
+		return m.TeamBID
+	default:
+		return m.TeamAID
+	}
+}
+
+func matchByLabel(matches []*models.Match, label string) *models.Match {
+	for _, m := range matches {
+		if m.Label == label {
+			return m
+		}
+	}
+	return nil
+}
+
+// groupStandings ranks a GSL group: the Winners match decides 1st, the Decider 2nd and 3rd,
+// and the loser of the Losers match is 4th. The group's Matches relation must be loaded.
+func groupStandings(g *models.Group) []Standing {
+	winnerOf := func(label string) uuid.UUID {
+		if m := matchByLabel(g.Matches, label); m != nil {
+			return m.WinnerID
+		}
+		return uuid.Nil
+	}
+	loserOf := func(label string) uuid.UUID {
+		if m := matchByLabel(g.Matches, label); m != nil {
+			return matchLoser(m)
+		}
+		return uuid.Nil
+	}
+
+	return []Standing{
+		{Rank: 1, TeamID: winnerOf("Winners")},
+		{Rank: 2, TeamID: winnerOf("Decider")},
+		{Rank: 3, TeamID: loserOf("Decider")},
+		{Rank: 4, TeamID: loserOf("Losers")},
+	}
+}
+
+// knockoutPlacements returns the podium of a knockout stage from its Final and Bronze matches.
+func knockoutPlacements(ko *models.Group) []Standing {
+	placements := []Standing{{Rank: 1}, {Rank: 2}, {Rank: 3}, {Rank: 4}}
+	if m := matchByLabel(ko.Matches, "Final"); m != nil {
+		placements[0].TeamID, placements[1].TeamID = m.WinnerID, matchLoser(m)
+	}
+	if m := matchByLabel(ko.Matches, "Bronze"); m != nil {
+		placements[2].TeamID, placements[3].TeamID = m.WinnerID, matchLoser(m)
+	}
+	return placements
+}
+
+// Placement is a team's final position in a category. Teams knocked out in the groups
+// share a rank with everyone who finished in the same group position.
+type Placement struct {
+	Rank      int       `json:"rank"`
+	TeamID    uuid.UUID `json:"team_id,omitempty"`
+	TeamName  string    `json:"team_name,omitempty"`
+	Players   []string  `json:"players,omitempty"`
+	Tied      bool      `json:"tied,omitempty"`
+	Stage     string    `json:"stage"`                // match or group position that decided the rank
+	GroupRank int       `json:"group_rank,omitempty"` // set for teams out in the groups
+}
+
+// categoryPlacements ranks every team of one category: 1st to 4th from the knockout stage,
+// then the remaining teams by group position. Positions not decided yet come back with
+// uuid.Nil for the podium and are left out below it; complete reports whether anything is
+// still open. Groups must have their Matches loaded.
+func categoryPlacements(groups []models.Group) (placements []Placement, complete bool) {
+	complete = true
+	placed := make(map[uuid.UUID]bool)
+
+	for i := range groups {
+		g := &groups[i]
+		if !isKnockoutGroup(g) {
+			continue
+		}
+		for _, s := range knockoutPlacements(g) {
+			stage := "Final"
+			if s.Rank > 2 {
+				stage = "Bronze"
+			}
+			placements = append(placements, Placement{Rank: s.Rank, TeamID: s.TeamID, Stage: stage})
+			if s.TeamID == uuid.Nil {
+				complete = false
+				continue
+			}
+			placed[s.TeamID] = true
+		}
+		break // one knockout stage per category
+	}
+
+	next := len(placements) + 1
+	for pos := 1; pos <= 4; pos++ {
+		var teams []uuid.UUID
+		for i := range groups {
+			g := &groups[i]
+			if isKnockoutGroup(g) {
+				continue
+			}
+			s := groupStandings(g)[pos-1]
+			if s.TeamID == uuid.Nil {
+				complete = false
+				continue
+			}
+			if !placed[s.TeamID] {
+				teams = append(teams, s.TeamID)
+				placed[s.TeamID] = true
+			}
+		}
+		for _, id := range teams {
+			placements = append(placements, Placement{
+				Rank:      next,
+				TeamID:    id,
+				Tied:      len(teams) > 1,
+				Stage:     fmt.Sprintf("Group rank %d", pos),
+				GroupRank: pos,
+			})
+		}
+		next += len(teams)
+	}
+	return placements, complete
+}
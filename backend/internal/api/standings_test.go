@@ -0,0 +1,126 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+)
+
+func decided(label string, winner, loser uuid.UUID) *models.Match {
+	return &models.Match{ID: uuid.New(), Label: label, TeamAID: winner, TeamBID: loser, WinnerID: winner}
+}
+
+// gslGroup returns a finished GSL group whose teams finished in the given order.
+func gslGroup(name string, first, second, third, fourth uuid.UUID) models.Group {
+	return models.Group{Name: name, Matches: []*models.Match{
+		decided("Winners", first, second),
+		decided("Decider", second, third),
+		decided("Losers", third, fourth),
+	}}
+}
+
+// knockoutGroup returns a finished knockout stage with the given podium.
+func knockoutGroup(champion, runnerUp, bronze, fourth uuid.UUID) models.Group {
+	return models.Group{Name: knockoutPrefix + " MensDoubles", Matches: []*models.Match{
+		decided("Final", champion, runnerUp),
+		decided("Bronze", bronze, fourth),
+	}}
+}
+
+func newTeamIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return ids
+}
+
+func TestCategoryPlacements(t *testing.T) {
+	a, b, c := newTeamIDs(4), newTeamIDs(4), newTeamIDs(4)
+	groups := []models.Group{
+		gslGroup("Group A", a[0], a[1], a[2], a[3]),
+		gslGroup("Group B", b[0], b[1], b[2], b[3]),
+		gslGroup("Group C", c[0], c[1], c[2], c[3]),
+		knockoutGroup(b[0], a[0], a[1], b[1]),
+	}
+
+	placements, complete := categoryPlacements(groups)
+	if !complete {
+		t.Error("complete = false for a finished category")
+	}
+	want := []Placement{
+		{Rank: 1, TeamID: b[0], Stage: "Final"},
+		{Rank: 2, TeamID: a[0], Stage: "Final"},
+		{Rank: 3, TeamID: a[1], Stage: "Bronze"},
+		{Rank: 4, TeamID: b[1], Stage: "Bronze"},
+		// Group C's top two had no knockout slot left; alone in their position, they are not tied
+		{Rank: 5, TeamID: c[0], Stage: "Group rank 1", GroupRank: 1},
+		{Rank: 6, TeamID: c[1], Stage: "Group rank 2", GroupRank: 2},
+		// Teams in the same group position share a rank, and the next rank skips past them
+		{Rank: 7, TeamID: a[2], Tied: true, Stage: "Group rank 3", GroupRank: 3},
+		{Rank: 7, TeamID: b[2], Tied: true, Stage: "Group rank 3", GroupRank: 3},
+		{Rank: 7, TeamID: c[2], Tied: true, Stage: "Group rank 3", GroupRank: 3},
+		{Rank: 10, TeamID: a[3], Tied: true, Stage: "Group rank 4", GroupRank: 4},
+		{Rank: 10, TeamID: b[3], Tied: true, Stage: "Group rank 4", GroupRank: 4},
+		{Rank: 10, TeamID: c[3], Tied: true, Stage: "Group rank 4", GroupRank: 4},
+	}
+	assertPlacements(t, placements, want)
+}
+
+func TestCategoryPlacementsUndecided(t *testing.T) {
+	a, b := newTeamIDs(4), newTeamIDs(4)
+	fourth := uuid.New()
+	groupB := gslGroup("Group B", b[0], b[1], b[2], b[3])
+	groupB.Matches[1].WinnerID = uuid.Nil // Decider not played
+	groups := []models.Group{gslGroup("Group A", a[0], a[1], a[2], a[3]), groupB, knockoutGroup(a[0], b[0], a[1], fourth)}
+
+	placements, complete := categoryPlacements(groups)
+	if complete {
+		t.Error("complete = true with a Decider still open")
+	}
+	// Group B's 2nd and 3rd are left out, so Group A's 3rd is alone in its position
+	want := []Placement{
+		{Rank: 1, TeamID: a[0], Stage: "Final"},
+		{Rank: 2, TeamID: b[0], Stage: "Final"},
+		{Rank: 3, TeamID: a[1], Stage: "Bronze"},
+		{Rank: 4, TeamID: fourth, Stage: "Bronze"},
+		{Rank: 5, TeamID: a[2], Stage: "Group rank 3", GroupRank: 3},
+		{Rank: 6, TeamID: a[3], Tied: true, Stage: "Group rank 4", GroupRank: 4},
+		{Rank: 6, TeamID: b[3], Tied: true, Stage: "Group rank 4", GroupRank: 4},
+	}
+	assertPlacements(t, placements, want)
+}
+
+func TestCategoryPlacementsOpenFinal(t *testing.T) {
+	a, b := newTeamIDs(4), newTeamIDs(4)
+	ko := knockoutGroup(a[0], b[0], a[1], b[1])
+	ko.Matches[0].WinnerID = uuid.Nil // Final not played
+
+	placements, complete := categoryPlacements([]models.Group{
+		gslGroup("Group A", a[0], a[1], a[2], a[3]),
+		gslGroup("Group B", b[0], b[1], b[2], b[3]),
+		ko,
+	})
+	if complete {
+		t.Error("complete = true with the Final still open")
+	}
+	for _, p := range placements[:2] {
+		if p.TeamID != uuid.Nil {
+			t.Errorf("placement %d = %s before the Final was played", p.Rank, p.TeamID)
+		}
+	}
+}
+
+func assertPlacements(t *testing.T, got, want []Placement) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d placements, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Rank != want[i].Rank || got[i].TeamID != want[i].TeamID || got[i].Tied != want[i].Tied ||
+			got[i].Stage != want[i].Stage || got[i].GroupRank != want[i].GroupRank {
+			t.Errorf("placement %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+// statsMaxAge bounds how long cached stats are served without rebuilding, for writes made
+// outside this server (CLI restores, direct SQL).
+const statsMaxAge = 5 * time.Minute
+
+// StatLine is a win/loss record over decided matches. Games and points come from SetsDetail;
+// walkovers count as played but add no games.
+type StatLine struct {
+	Played           int     `json:"played"`
+	Won              int     `json:"won"`
+	Lost             int     `json:"lost"`
+	Walkovers        int     `json:"walkovers"`
+	SetsWon          int     `json:"sets_won"`
+	SetsLost         int     `json:"sets_lost"`
+	PointsWon        int     `json:"points_won"`
+	PointsLost       int     `json:"points_lost"`
+	WinRate          float64 `json:"win_rate"`
+	LongestWinStreak int     `json:"longest_win_streak"`
+
+	streak int
+}
+
+func (s *StatLine) add(r matchResult) {
+	s.Played++
+	if r.won {
+		s.Won++
+		s.streak++
+		if s.streak > s.LongestWinStreak {
+			s.LongestWinStreak = s.streak
+		}
+	} else {
+		s.Lost++
+		s.streak = 0
+	}
+	if r.walkover {
+		s.Walkovers++
+	}
+	for _, g := range r.games {
+		won, lost := g.A, g.B
+		if !r.sideA {
+			won, lost = g.B, g.A
+		}
+		s.PointsWon += won
+		s.PointsLost += lost
+		switch service.GameWinner(won, lost) {
+		case "A":
+			s.SetsWon++
+		case "B":
+			s.SetsLost++
+		}
+	}
+	s.WinRate = float64(s.Won) / float64(s.Played)
+}
+
+// matchResult is one decided match seen from one team.
+type matchResult struct {
+	won      bool
+	walkover bool
+	sideA    bool
+	games    []service.Game
+}
+
+type TeamStats struct {
+	TeamID   uuid.UUID `json:"team_id"`
+	Name     string    `json:"name"`
+	Category string    `json:"category"`
+	StatLine
+}
+
+// ParticipantStats aggregates every team the participant played in.
+type ParticipantStats struct {
+	ParticipantID uuid.UUID            `json:"participant_id"`
+	Name          string               `json:"name"`
+	Pool          string               `json:"pool"`
+	ByCategory    map[string]*StatLine `json:"by_category"`
+	Teams         []*TeamStats         `json:"teams"`
+	StatLine
+}
+
+type statsIndex struct {
+	teams        map[uuid.UUID]*TeamStats
+	participants map[uuid.UUID]*ParticipantStats
+}
+
+// statsCache keeps the last computed stats. It is shared by a handler and its transaction
+// copies, and dropped by recordAudit, which every result change calls once it has committed.
+type statsCache struct {
+	mu      sync.Mutex
+	builtAt time.Time
+	index   *statsIndex
+}
+
+func (sc *statsCache) invalidate() {
+	sc.mu.Lock()
+	sc.index = nil
+	sc.mu.Unlock()
+}
+
+func (h *Handler) loadStats(ctx context.Context) (*statsIndex, error) {
+	h.stats.mu.Lock()
+	defer h.stats.mu.Unlock()
+	if h.stats.index != nil && time.Since(h.stats.builtAt) < statsMaxAge {
+		return h.stats.index, nil
+	}
+
+	index, err := h.buildStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	h.stats.index, h.stats.builtAt = index, time.Now()
+	return index, nil
+}
+
+// buildStats replays every decided match in play order (see playedBefore); longest streaks
+// depend on that order.
+func (h *Handler) buildStats(ctx context.Context) (*statsIndex, error) {
+	var tournaments []models.Tournament
+	if err := h.DB.NewSelect().Model(&tournaments).Column("id", "created_at").Scan(ctx); err != nil {
+		return nil, err
+	}
+	started := make(map[uuid.UUID]time.Time, len(tournaments))
+	for _, t := range tournaments {
+		started[t.ID] = t.CreatedAt
+	}
+
+	var groups []models.Group
+	if err := h.DB.NewSelect().Model(&groups).Relation("Matches").Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	var participants []models.Participant
+	if err := h.DB.NewSelect().Model(&participants).Column("id", "name", "pool").Scan(ctx); err != nil {
+		return nil, err
+	}
+	var teams []models.Team
+	if err := h.DB.NewSelect().Model(&teams).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	index := &statsIndex{
+		teams:        make(map[uuid.UUID]*TeamStats, len(teams)),
+		participants: make(map[uuid.UUID]*ParticipantStats, len(participants)),
+	}
+	for _, p := range participants {
+		index.participants[p.ID] = &ParticipantStats{
+			ParticipantID: p.ID,
+			Name:          p.Name,
+			Pool:          p.Pool,
+			ByCategory:    make(map[string]*StatLine),
+			Teams:         []*TeamStats{},
+		}
+	}
+	players := make(map[uuid.UUID][]*ParticipantStats, len(teams))
+	for _, t := range teams {
+		ts := &TeamStats{TeamID: t.ID, Name: t.Name, Category: t.Category}
+		index.teams[t.ID] = ts
+		for _, id := range []uuid.UUID{t.Player1ID, t.Player2ID} {
+			if ps, ok := index.participants[id]; ok {
+				ps.Teams = append(ps.Teams, ts)
+				players[t.ID] = append(players[t.ID], ps)
+			}
+		}
+	}
+
+	type played struct {
+		match *models.Match
+		group *models.Group
+	}
+	var decided []played
+	for i := range groups {
+		for _, m := range groups[i].Matches {
+			if m.WinnerID != uuid.Nil {
+				decided = append(decided, played{m, &groups[i]})
+			}
+		}
+	}
+	sort.SliceStable(decided, func(i, j int) bool {
+		a, b := decided[i], decided[j]
+		return playedBefore(started[a.group.TournamentID], started[b.group.TournamentID], a.group, b.group, a.match, b.match)
+	})
+
+	for _, d := range decided {
+		m := d.match
+		games, err := matchGames(m)
+		if err != nil {
+			log.Printf("[Stats] WARNING: %s %s: %v", d.group.Name, m.Label, err)
+		}
+		for _, teamID := range []uuid.UUID{m.TeamAID, m.TeamBID} {
+			ts, ok := index.teams[teamID]
+			if !ok {
+				continue
+			}
+			r := matchResult{
+				won:      m.WinnerID == teamID,
+				walkover: m.Score == walkoverScore,
+				sideA:    teamID == m.TeamAID,
+				games:    games,
+			}
+			ts.add(r)
+			for _, ps := range players[teamID] {
+				ps.add(r)
+				line, ok := ps.ByCategory[ts.Category]
+				if !ok {
+					line = &StatLine{}
+					ps.ByCategory[ts.Category] = line
+				}
+				line.add(r)
+			}
+		}
+	}
+	return index, nil
+}
+
+// playedBefore orders matches the way they were played, as far as the schema tells: by
+// tournament start, group stage before knockout, then round. Matches carry no timestamps.
+func playedBefore(startA, startB time.Time, ga, gb *models.Group, a, b *models.Match) bool {
+	if !startA.Equal(startB) {
+		return startA.Before(startB)
+	}
+	if ka, kb := isKnockoutGroup(ga), isKnockoutGroup(gb); ka != kb {
+		return kb
+	}
+	if oa, ob := printMatchOrder[a.Label], printMatchOrder[b.Label]; oa != ob {
+		return oa < ob
+	}
+	return ga.Name < gb.Name
+}
+
+// matchGames returns the games of a decided match. Results entered before SetsDetail existed
+// keep the games in Score ("21-19, 21-18"); a sets summary such as "2-0" is not mistaken for
+// games because those are not finished game scores.
+func matchGames(m *models.Match) ([]service.Game, error) {
+	if m.SetsDetail != "" {
+		return service.ParseGames(m.SetsDetail)
+	}
+	if m.Score == walkoverScore {
+		return nil, nil
+	}
+	games, err := service.ParseGames(m.Score)
+	if err != nil {
+		return nil, nil
+	}
+	for _, g := range games {
+		if service.GameWinner(g.A, g.B) == "" {
+			return nil, nil
+		}
+	}
+	return games, nil
+}
+
+// ListTeamStats returns team records, best win rate first
+// GET /api/stats/teams?category=MensDoubles
+func (h *Handler) ListTeamStats(c *gin.Context) {
+	index, err := h.loadStats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	category := c.Query("category")
+	result := []*TeamStats{}
+	for _, ts := range index.teams {
+		if ts.Played > 0 && (category == "" || ts.Category == category) {
+			result = append(result, ts)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessStatLine(&result[i].StatLine, &result[j].StatLine, result[i].Name, result[j].Name)
+	})
+	c.JSON(http.StatusOK, result)
+}
+
+// GetTeamStats returns one team's record
+// GET /api/stats/teams/:id
+func (h *Handler) GetTeamStats(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+	index, err := h.loadStats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ts, ok := index.teams[id]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+	c.JSON(http.StatusOK, ts)
+}
+
+// ListParticipantStats returns player records, best win rate first
+// GET /api/stats/participants?pool=Lab
+func (h *Handler) ListParticipantStats(c *gin.Context) {
+	index, err := h.loadStats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	pool := c.Query("pool")
+	result := []*ParticipantStats{}
+	for _, ps := range index.participants {
+		if ps.Played > 0 && (pool == "" || ps.Pool == pool) {
+			result = append(result, ps)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessStatLine(&result[i].StatLine, &result[j].StatLine, result[i].Name, result[j].Name)
+	})
+	c.JSON(http.StatusOK, result)
+}
+
+// GetParticipantStats returns a player's profile: overall, per category and per team
+// GET /api/stats/participants/:id
+func (h *Handler) GetParticipantStats(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid participant ID"})
+		return
+	}
+	index, err := h.loadStats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ps, ok := index.participants[id]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Participant not found"})
+		return
+	}
+	c.JSON(http.StatusOK, ps)
+}
+
+// lessStatLine orders by win rate, then wins, then point difference, then name.
+func lessStatLine(a, b *StatLine, nameA, nameB string) bool {
+	if a.WinRate != b.WinRate {
+		return a.WinRate > b.WinRate
+	}
+	if a.Won != b.Won {
+		return a.Won > b.Won
+	}
+	if da, db := a.PointsWon-a.PointsLost, b.PointsWon-b.PointsLost; da != db {
+		return da > db
+	}
+	return nameA < nameB
+}
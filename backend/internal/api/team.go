@@ -1,28 +1,15 @@
 package api
 
 import (
+	"context"
 	"net/http"
-	"time"
-	"math/rand"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 	"badminton_tournament/backend/internal/models"
-	"strings"
+	"badminton_tournament/backend/internal/service"
 )
- 
- func isMale(g string) bool {
-	g = strings.TrimSpace(strings.ToLower(g))
-	return g == "male" || g == "man" || g == "m" || g == "nam"
-}
-
-func isFemale(g string) bool {
-	g = strings.TrimSpace(strings.ToLower(g))
-	return g == "female" || g == "woman" || g == "f" || g == "w" || g == "nữ" || g == "nu"
-}
-
-
 
 func (h *Handler) ListTeams(c *gin.Context) {
 	pool := c.Query("pool")
@@ -72,6 +59,9 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !h.requirePhase(c, DefaultTournamentID, service.OpEditTeams) {
+		return
+	}
 
 	ctx := c.Request.Context()
 
@@ -89,24 +79,27 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 	// 3. Validate Gender based on Category
 
 	if req.Category == "MensDoubles" {
-		if !isMale(p1.Gender) || !isMale(p2.Gender) {
+		if !service.IsMale(p1.Gender) || !service.IsMale(p2.Gender) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Men's Doubles requires both players to be Male"})
 			return
 		}
 	} else if req.Category == "MixedDoubles" {
 		// Must be opposite genders
-		if (isMale(p1.Gender) && isMale(p2.Gender)) || (isFemale(p1.Gender) && isFemale(p2.Gender)) {
+		if (service.IsMale(p1.Gender) && service.IsMale(p2.Gender)) || (service.IsFemale(p1.Gender) && service.IsFemale(p2.Gender)) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Mixed Doubles requires one Male and one Female player"})
 			return
 		}
 	}
 
 	// 4. Validate Availability: Check if players are already in a team for this category
-	count, _ := h.DB.NewSelect().Model((*models.Team)(nil)).
+	count, err := h.DB.NewSelect().Model((*models.Team)(nil)).
 		Where("(player1_id IN (?) OR player2_id IN (?))", bun.In([]string{req.Player1ID, req.Player2ID}), bun.In([]string{req.Player1ID, req.Player2ID})).
 		Where("category = ?", req.Category).
 		Count(ctx)
-	
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	if count > 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "One or both players are already in a team for this category"})
 		return
@@ -125,6 +118,7 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.recordAudit(c, "CreateTeam", "team", team.ID.String(), nil, team)
 
 	c.JSON(http.StatusCreated, team)
 }
@@ -143,6 +137,9 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !h.requirePhase(c, DefaultTournamentID, service.OpEditTeams) {
+		return
+	}
 
 	ctx := c.Request.Context()
 	var team models.Team
@@ -150,6 +147,21 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
 		return
 	}
+	before := team
+
+	// Results belong to the pair that played them
+	played, err := h.DB.NewSelect().Model((*models.Match)(nil)).
+		Where("team_a_id = ? OR team_b_id = ?", team.ID, team.ID).
+		Where("winner_id IS NOT NULL").
+		Count(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if played > 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot change a team that has finished matches"})
+		return
+	}
 
 	// If P1 passed, update
 	if req.Player1ID != "" {
@@ -160,7 +172,7 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 				return
 			}
 			// Category Validation for P1
-			if team.Category == "MensDoubles" && !isMale(p.Gender) {
+			if team.Category == "MensDoubles" && !service.IsMale(p.Gender) {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Men's Doubles requires Male players"})
 				return
 			}
@@ -177,7 +189,7 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 				return
 			}
 			// Category Validation for P2
-			if team.Category == "MensDoubles" && !isMale(p.Gender) {
+			if team.Category == "MensDoubles" && !service.IsMale(p.Gender) {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Men's Doubles requires Male players"})
 				return
 			}
@@ -190,7 +202,7 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 		var p1, p2 models.Participant
 		h.DB.NewSelect().Model(&p1).Where("id = ?", team.Player1ID).Scan(ctx)
 		h.DB.NewSelect().Model(&p2).Where("id = ?", team.Player2ID).Scan(ctx)
-		if (isMale(p1.Gender) && isMale(p2.Gender)) || (isFemale(p1.Gender) && isFemale(p2.Gender)) {
+		if (service.IsMale(p1.Gender) && service.IsMale(p2.Gender)) || (service.IsFemale(p1.Gender) && service.IsFemale(p2.Gender)) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Mixed Doubles requires one Male and one Female player"})
 			return
 		}
@@ -208,6 +220,7 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.recordAudit(c, "UpdateTeam", "team", team.ID.String(), before, team)
 
 	c.JSON(http.StatusOK, team)
 }
@@ -216,22 +229,36 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 func (h *Handler) DeleteTeam(c *gin.Context) {
 	id := c.Param("id")
 	ctx := c.Request.Context()
+	if !h.requirePhase(c, DefaultTournamentID, service.OpEditTeams) {
+		return
+	}
 
 	// Check for finished matches?
-	count, _ := h.DB.NewSelect().Model((*models.Match)(nil)).
+	count, err := h.DB.NewSelect().Model((*models.Match)(nil)).
 		Where("team_a_id = ? OR team_b_id = ?", id, id).
 		Where("winner_id IS NOT NULL").
 		Count(ctx)
-	
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	if count > 0 {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot disband team that has finished matches"})
 		return
 	}
 
+	// Snapshot for the audit log; a missing team is still a no-op delete
+	var before *models.Team
+	var team models.Team
+	if err := h.DB.NewSelect().Model(&team).Where("id = ?", id).Scan(ctx); err == nil {
+		before = &team
+	}
+
 	if _, err := h.DB.NewDelete().Model((*models.Team)(nil)).Where("id = ?", id).Exec(ctx); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.recordAudit(c, "DeleteTeam", "team", id, before, nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Team disbanded"})
 }
@@ -249,109 +276,44 @@ func (h *Handler) AutoPairTeams(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	ctx := c.Request.Context()
-
-	// 1. Fetch ALL Participants
-	var participants []models.Participant
-	if err := h.DB.NewSelect().Model(&participants).Scan(ctx); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch participants"})
+	if !h.requirePhase(c, DefaultTournamentID, service.OpEditTeams) {
 		return
 	}
 
-	// 2. Fetch Existing Teams to find BUSY participants in this category
-	var existingTeams []models.Team
-	if err := h.DB.NewSelect().Model(&existingTeams).Where("category = ?", req.Category).Scan(ctx); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch teams"})
+	newTeams, err := h.Service.AutoPairTeams(c.Request.Context(), req.Category)
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
-
-	busyMap := make(map[uuid.UUID]bool)
-	for _, t := range existingTeams {
-		busyMap[t.Player1ID] = true
-		busyMap[t.Player2ID] = true
-	}
-
-	// 3. Group Free Participants by Pool and Gender
-	type poolGender struct {
-		freeMales   []models.Participant
-		freeFemales []models.Participant
-	}
-	freeByPool := make(map[string]*poolGender)
-	for _, p := range participants {
-		if !busyMap[p.ID] {
-			if _, ok := freeByPool[p.Pool]; !ok {
-				freeByPool[p.Pool] = &poolGender{}
-			}
-			if isMale(p.Gender) {
-				freeByPool[p.Pool].freeMales = append(freeByPool[p.Pool].freeMales, p)
-			} else if isFemale(p.Gender) {
-				freeByPool[p.Pool].freeFemales = append(freeByPool[p.Pool].freeFemales, p)
-			}
-		}
-	}
-
-	// 4. Pair Based on Category
-	rand.Seed(time.Now().UnixNano())
-	var newTeams []models.Team
-
-	for pool, data := range freeByPool {
-		if req.Category == "MensDoubles" {
-			// Only pair males
-			males := data.freeMales
-			rand.Shuffle(len(males), func(i, j int) {
-				males[i], males[j] = males[j], males[i]
-			})
-			for i := 0; i < len(males)-1; i += 2 {
-				newTeams = append(newTeams, models.Team{
-					Player1ID: males[i].ID,
-					Player2ID: males[i+1].ID,
-					Pool:      pool,
-					Name:      males[i].Name + " & " + males[i+1].Name,
-					Category:  req.Category,
-				})
-			}
-		} else if req.Category == "MixedDoubles" {
-			// Pair 1 Male + 1 Female
-			males := data.freeMales
-			females := data.freeFemales
-			rand.Shuffle(len(males), func(i, j int) {
-				males[i], males[j] = males[j], males[i]
-			})
-			rand.Shuffle(len(females), func(i, j int) {
-				females[i], females[j] = females[j], females[i]
-			})
-
-			// Pair up as much as possible
-			limit := len(males)
-			if len(females) < limit {
-				limit = len(females)
-			}
-			for i := 0; i < limit; i++ {
-				newTeams = append(newTeams, models.Team{
-					Player1ID: males[i].ID,
-					Player2ID: females[i].ID,
-					Pool:      pool,
-					Name:      males[i].Name + " & " + females[i].Name,
-					Category:  req.Category,
-				})
-			}
-		}
-	}
-
 	if len(newTeams) == 0 {
 		c.JSON(http.StatusOK, gin.H{"message": "No new teams created", "count": 0})
 		return
 	}
-
-	// 5. Bulk Insert
-	if _, err := h.DB.NewInsert().Model(&newTeams).Exec(ctx); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create teams: " + err.Error()})
-		return
-	}
+	h.recordAudit(c, "AutoPairTeams", "team", "", nil, newTeams)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Teams auto-paired successfully",
 		"count":   len(newTeams),
 	})
 }
+
+// refreshTeamNames rebuilds the "P1 & P2" name of every team the participant plays in.
+func refreshTeamNames(ctx context.Context, db bun.IDB, participantID uuid.UUID) error {
+	var teams []models.Team
+	if err := db.NewSelect().Model(&teams).Relation("Player1").Relation("Player2").
+		Where("tm.player1_id = ? OR tm.player2_id = ?", participantID, participantID).
+		Scan(ctx); err != nil {
+		return err
+	}
+	for i := range teams {
+		t := &teams[i]
+		if t.Player1 == nil || t.Player2 == nil {
+			continue
+		}
+		t.Name = t.Player1.Name + " & " + t.Player2.Name
+		if _, err := db.NewUpdate().Model(t).Column("name").WherePK().Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
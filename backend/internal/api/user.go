@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"golang.org/x/crypto/bcrypt"
+	"badminton_tournament/backend/internal/models"
+)
+
+const minPasswordLength = 8
+
+// validRoles are the Casbin subjects defined in auth/policy.csv
+var validRoles = map[string]bool{
+	"admin":   true,
+	"referee": true,
+	"viewer":  true,
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func checkPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// CreateUser validates and stores a new account. Shared by the admin API and cmd/create_admin.
+func CreateUser(ctx context.Context, db bun.IDB, username, password, role string) (*models.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if !validRoles[role] {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username:     username,
+		PasswordHash: hash,
+		Role:         role,
+	}
+	if _, err := db.NewInsert().Model(user).Returning("*").Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create user %q: %w", username, err)
+	}
+	return user, nil
+}
+
+// BootstrapAdmin creates the first admin from ADMIN_USERNAME (default "admin") and ADMIN_PASSWORD
+// when the users table is empty, so hosted deployments can log in without running cmd/create_admin.
+func BootstrapAdmin(ctx context.Context, db bun.IDB) error {
+	count, err := db.NewSelect().Model((*models.User)(nil)).Count(ctx)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	password := os.Getenv("ADMIN_PASSWORD")
+	if password == "" {
+		log.Println("WARNING: No user accounts exist. Set ADMIN_PASSWORD or run cmd/create_admin to create the first admin.")
+		return nil
+	}
+	username := os.Getenv("ADMIN_USERNAME")
+	if username == "" {
+		username = "admin"
+	}
+
+	user, err := CreateUser(ctx, db, username, password, "admin")
+	if err != nil {
+		return err
+	}
+	log.Printf("Bootstrapped admin account '%s'", user.Username)
+	return nil
+}
+
+type CreateUserRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Role     string `json:"role" binding:"required"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// ListUsers returns all accounts (without password hashes)
+// GET /api/admin/users
+func (h *Handler) ListUsers(c *gin.Context) {
+	var users []models.User
+	if err := h.DB.NewSelect().Model(&users).Order("username ASC").Scan(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// CreateUserAccount adds a named account with a role
+// POST /api/admin/users
+func (h *Handler) CreateUserAccount(c *gin.Context) {
+	var req CreateUserRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := CreateUser(c.Request.Context(), h.DB, req.Username, req.Password, req.Role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "CreateUser", "user", user.ID.String(), nil, user)
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// DeleteUser removes an account. Admins cannot delete themselves to avoid locking everyone out.
+// DELETE /api/admin/users/:id
+func (h *Handler) DeleteUser(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	var user models.User
+	if err := h.DB.NewSelect().Model(&user).Where("id = ?", id).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if user.ID.String() == userIDFromContext(c) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete your own account"})
+		return
+	}
+
+	if _, err := h.DB.NewDelete().Model(&user).WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "DeleteUser", "user", user.ID.String(), user, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted"})
+}
+
+// UpdateUserRole changes an account's role. It applies to tokens already issued, since
+// AuthMiddleware reads the role from the account. Admins cannot demote themselves.
+// PUT /api/admin/users/:id/role
+func (h *Handler) UpdateUserRole(c *gin.Context) {
+	var req UpdateUserRoleRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validRoles[req.Role] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown role %q", req.Role)})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var user models.User
+	if err := h.DB.NewSelect().Model(&user).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if user.ID.String() == userIDFromContext(c) && req.Role != user.Role {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change your own role"})
+		return
+	}
+
+	before := user
+	user.Role = req.Role
+	user.UpdatedAt = time.Now()
+	if _, err := h.DB.NewUpdate().Model(&user).Column("role", "updated_at").WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "UpdateUserRole", "user", user.ID.String(), before, user)
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangePassword lets the logged-in user replace their own password
+// PUT /api/auth/password
+func (h *Handler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uid, err := uuid.Parse(userIDFromContext(c))
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Token is not bound to a user account"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var user models.User
+	if err := h.DB.NewSelect().Model(&user).Where("id = ?", uid).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if !checkPassword(user.PasswordHash, req.CurrentPassword) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Current password is incorrect"})
+		return
+	}
+
+	hash, err := hashPassword(req.NewPassword)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user.PasswordHash = hash
+	user.UpdatedAt = time.Now()
+	if _, err := h.DB.NewUpdate().Model(&user).Column("password_hash", "updated_at").WherePK().Exec(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordAudit(c, "ChangePassword", "user", user.ID.String(), nil, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password updated"})
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"badminton_tournament/backend/internal/models"
+)
+
+// Webhook signing scheme (see docs/webhook_signing.md):
+//
+//	X-Webhook-Source:    source name, selects the shared secret (default "google-form")
+//	X-Webhook-Timestamp: unix seconds at signing time
+//	X-Webhook-Nonce:     unique per delivery (e.g. a UUID)
+//	X-Webhook-Signature: "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + nonce + "." + body))
+const (
+	headerWebhookSource    = "X-Webhook-Source"
+	headerWebhookTimestamp = "X-Webhook-Timestamp"
+	headerWebhookNonce     = "X-Webhook-Nonce"
+	headerWebhookSignature = "X-Webhook-Signature"
+
+	defaultWebhookSource = "google-form"
+	webhookTolerance     = 5 * time.Minute
+	maxWebhookBodyBytes  = 1 << 20
+)
+
+// webhookSecrets parses WEBHOOK_SECRETS, a comma separated list of "source:secret" pairs,
+// e.g. "google-form:abc123,ms-forms:def456".
+func webhookSecrets() map[string]string {
+	secrets := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv("WEBHOOK_SECRETS"), ",") {
+		source, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if ok && source != "" && secret != "" {
+			secrets[source] = secret
+		}
+	}
+	return secrets
+}
+
+func signWebhook(secret, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSignatureMiddleware rejects form submissions that are unsigned, signed with the wrong
+// secret, older than the tolerance window, or replayed with a nonce that was already used.
+func (h *Handler) WebhookSignatureMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		source := c.GetHeader(headerWebhookSource)
+		if source == "" {
+			source = defaultWebhookSource
+		}
+
+		secret, ok := webhookSecrets()[source]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unknown webhook source"})
+			return
+		}
+
+		timestamp := c.GetHeader(headerWebhookTimestamp)
+		nonce := c.GetHeader(headerWebhookNonce)
+		signature := c.GetHeader(headerWebhookSignature)
+		if timestamp == "" || nonce == "" || signature == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing webhook signature headers"})
+			return
+		}
+
+		ts, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid webhook timestamp"})
+			return
+		}
+		age := time.Since(time.Unix(ts, 0))
+		if age > webhookTolerance || age < -webhookTolerance {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Stale webhook timestamp"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Webhook body exceeds %d bytes", maxWebhookBodyBytes)})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read body"})
+			return
+		}
+		// Hand the body back to the handler
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		expected := signWebhook(secret, timestamp, nonce, body)
+		if !hmac.Equal([]byte(expected), []byte(signature)) {
+			log.Printf("[Webhook] Rejected delivery from %s: bad signature", source)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid webhook signature"})
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		// Nonces only need to outlive the tolerance window; older ones are rejected by timestamp anyway
+		if _, err := h.DB.NewDelete().Model((*models.WebhookNonce)(nil)).
+			Where("created_at < ?", time.Now().Add(-2*webhookTolerance)).
+			Exec(ctx); err != nil {
+			log.Printf("[Webhook] WARNING: failed to prune nonces: %v", err)
+		}
+
+		res, err := h.DB.NewInsert().Model(&models.WebhookNonce{Nonce: nonce, Source: source}).
+			On("CONFLICT (nonce) DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if rows, _ := res.RowsAffected(); rows == 0 {
+			log.Printf("[Webhook] Rejected replayed delivery from %s (nonce %s)", source, nonce)
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "Webhook nonce already used"})
+			return
+		}
+
+		c.Set("webhook_source", source)
+		c.Next()
+	}
+}
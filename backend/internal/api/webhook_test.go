@@ -0,0 +1,186 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testWebhookSecret = "s3cret"
+
+// newWebhookRouter serves the signature middleware in front of a handler that echoes the body.
+func newWebhookRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	t.Setenv("WEBHOOK_SECRETS", "google-form:"+testWebhookSecret+",ms-forms:other")
+	h := newTestHandler(t)
+	r := gin.New()
+	r.POST("/hook", h.WebhookSignatureMiddleware(), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, c.GetString("webhook_source")+":"+string(body))
+	})
+	return r
+}
+
+type webhookDelivery struct {
+	source    string
+	secret    string
+	timestamp string
+	nonce     string
+	body      []byte
+	signed    []byte // body the signature covers, if it differs from body
+}
+
+func (d webhookDelivery) send(r *gin.Engine) *httptest.ResponseRecorder {
+	signed := d.signed
+	if signed == nil {
+		signed = d.body
+	}
+	signature := signWebhook(d.secret, d.timestamp, d.nonce, signed)
+	req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(d.body))
+	if d.source != "" {
+		req.Header.Set(headerWebhookSource, d.source)
+	}
+	req.Header.Set(headerWebhookTimestamp, d.timestamp)
+	req.Header.Set(headerWebhookNonce, d.nonce)
+	req.Header.Set(headerWebhookSignature, signature)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func unixAt(offset time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(offset).Unix(), 10)
+}
+
+func TestWebhookSignatureMiddleware(t *testing.T) {
+	body := []byte(`{"name":"Anh"}`)
+	tests := []struct {
+		name     string
+		delivery webhookDelivery
+		want     int
+	}{
+		{
+			name:     "valid signature",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(0), body: body},
+			want:     http.StatusOK,
+		},
+		{
+			name:     "valid signature for a named source",
+			delivery: webhookDelivery{source: "ms-forms", secret: "other", timestamp: unixAt(0), body: body},
+			want:     http.StatusOK,
+		},
+		{
+			name:     "wrong secret",
+			delivery: webhookDelivery{secret: "guess", timestamp: unixAt(0), body: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "another source's secret",
+			delivery: webhookDelivery{secret: "other", timestamp: unixAt(0), body: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "body changed after signing",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(0), body: []byte(`{"name":"Binh"}`), signed: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "unknown source",
+			delivery: webhookDelivery{source: "typeform", secret: testWebhookSecret, timestamp: unixAt(0), body: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "missing timestamp",
+			delivery: webhookDelivery{secret: testWebhookSecret, body: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "malformed timestamp",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: "yesterday", body: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "just inside the tolerance",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(-webhookTolerance + 10*time.Second), body: body},
+			want:     http.StatusOK,
+		},
+		{
+			name:     "just past the tolerance",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(-webhookTolerance - 10*time.Second), body: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "too far in the future",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(webhookTolerance + 10*time.Second), body: body},
+			want:     http.StatusUnauthorized,
+		},
+		{
+			name:     "body at the size limit",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(0), body: bytes.Repeat([]byte("x"), maxWebhookBodyBytes)},
+			want:     http.StatusOK,
+		},
+		{
+			name:     "body over the size limit",
+			delivery: webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(0), body: bytes.Repeat([]byte("x"), maxWebhookBodyBytes+1)},
+			want:     http.StatusRequestEntityTooLarge,
+		},
+	}
+	r := newWebhookRouter(t)
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.delivery.nonce = "nonce-" + strconv.Itoa(i)
+			w := tt.delivery.send(r)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			// The handler sees the body that was verified
+			source := tt.delivery.source
+			if source == "" {
+				source = defaultWebhookSource
+			}
+			if got := w.Body.String(); got != source+":"+string(tt.delivery.body) {
+				t.Errorf("handler saw %.60q", got)
+			}
+		})
+	}
+}
+
+func TestWebhookNonceReplay(t *testing.T) {
+	r := newWebhookRouter(t)
+	body := []byte(`{"name":"Anh"}`)
+	first := webhookDelivery{secret: testWebhookSecret, timestamp: unixAt(0), nonce: "once", body: body}
+
+	if w := first.send(r); w.Code != http.StatusOK {
+		t.Fatalf("first delivery: status = %d: %s", w.Code, w.Body.String())
+	}
+	if w := first.send(r); w.Code != http.StatusConflict {
+		t.Errorf("replayed delivery: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	// A replay with a fresh timestamp is still the same nonce
+	replay := first
+	replay.timestamp = unixAt(time.Second)
+	if w := replay.send(r); w.Code != http.StatusConflict {
+		t.Errorf("replay with a new timestamp: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	// Rejected deliveries do not use up their nonce
+	forged := webhookDelivery{secret: "guess", timestamp: unixAt(0), nonce: "fresh", body: body}
+	if w := forged.send(r); w.Code != http.StatusUnauthorized {
+		t.Fatalf("forged delivery: status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	genuine := forged
+	genuine.secret = testWebhookSecret
+	if w := genuine.send(r); w.Code != http.StatusOK {
+		t.Errorf("genuine delivery after a forged one: status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+const walkoverScore = "W/O"
+
+// errDryRun rolls back the withdrawal transaction after the impact has been computed.
+var errDryRun = errors.New("dry run")
+
+// WithdrawalImpact describes what withdrawing a participant does (or would do).
+type WithdrawalImpact struct {
+	Participant      models.Participant `json:"participant"`
+	Teams            []models.Team      `json:"teams"`
+	Walkovers        []WalkoverResult   `json:"walkovers"`
+	AwaitingOpponent []models.Match     `json:"awaiting_opponent"` // Re-run the withdrawal once these are filled
+	DryRun           bool               `json:"dry_run"`
+}
+
+// WalkoverResult is one pending match awarded to the opponent of a withdrawn team.
+type WalkoverResult struct {
+	MatchID  uuid.UUID `json:"match_id"`
+	GroupID  uuid.UUID `json:"group_id"`
+	Label    string    `json:"label"`
+	LoserID  uuid.UUID `json:"loser_id"`
+	WinnerID uuid.UUID `json:"winner_id"`
+}
+
+// withdrawParticipant marks the participant withdrawn and awards every pending match of their
+// teams to the opponent. Walkovers propagate like normal results, which can route a withdrawn
+// team into a further match (e.g. the GSL Losers match); those are resolved in the next pass.
+func (h *Handler) withdrawParticipant(ctx context.Context, participantID string) (*WithdrawalImpact, error) {
+	impact := &WithdrawalImpact{Walkovers: []WalkoverResult{}, AwaitingOpponent: []models.Match{}}
+
+	if err := h.DB.NewSelect().Model(&impact.Participant).Where("id = ?", participantID).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := h.DB.NewSelect().Model(&impact.Teams).
+		Where("player1_id = ? OR player2_id = ?", impact.Participant.ID, impact.Participant.ID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	withdrawn := make(map[uuid.UUID]bool, len(impact.Teams))
+	teamIDs := make([]uuid.UUID, 0, len(impact.Teams))
+	for _, t := range impact.Teams {
+		withdrawn[t.ID] = true
+		teamIDs = append(teamIDs, t.ID)
+	}
+
+	// Each pass settles at least one match, so the number of matches bounds the loop
+	for len(teamIDs) > 0 {
+		var pending []models.Match
+		if err := h.DB.NewSelect().Model(&pending).
+			Where("winner_id IS NULL").
+			Where("team_a_id IN (?) OR team_b_id IN (?)", bun.In(teamIDs), bun.In(teamIDs)).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		settled := false
+		impact.AwaitingOpponent = impact.AwaitingOpponent[:0]
+		for i := range pending {
+			m := &pending[i]
+
+			loser, opponent := m.TeamAID, m.TeamBID
+			if !withdrawn[loser] {
+				loser, opponent = m.TeamBID, m.TeamAID
+			}
+			if opponent == uuid.Nil {
+				impact.AwaitingOpponent = append(impact.AwaitingOpponent, *m)
+				continue
+			}
+
+			m.WinnerID = opponent
+			m.Score = walkoverScore
+			m.SetsDetail = ""
+			if _, err := h.DB.NewUpdate().Model(m).Column("winner_id", "score", "sets_detail").WherePK().Exec(ctx); err != nil {
+				return nil, err
+			}
+			if err := h.Service.PropagateResult(ctx, m, opponent); err != nil {
+				return nil, err
+			}
+
+			impact.Walkovers = append(impact.Walkovers, WalkoverResult{
+				MatchID:  m.ID,
+				GroupID:  m.GroupID,
+				Label:    m.Label,
+				LoserID:  loser,
+				WinnerID: opponent,
+			})
+			settled = true
+		}
+
+		if !settled {
+			break
+		}
+	}
+
+	impact.Participant.Status = models.ParticipantWithdrawn
+	if _, err := h.DB.NewUpdate().Model(&impact.Participant).Column("status").WherePK().Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return impact, nil
+}
+
+// WithdrawParticipant withdraws a participant from the tournament. With ?dry_run=true the
+// cascade is computed inside a transaction that is rolled back, so nothing changes.
+// POST /api/admin/participants/:id/withdraw
+func (h *Handler) WithdrawParticipant(c *gin.Context) {
+	dryRun := c.Query("dry_run") == "true"
+	if !dryRun && !h.requirePhase(c, DefaultTournamentID, service.OpWithdraw) {
+		return
+	}
+	ctx := c.Request.Context()
+
+	var before models.Participant
+	if err := h.DB.NewSelect().Model(&before).Where("id = ?", c.Param("id")).Scan(ctx); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Participant not found"})
+		return
+	}
+
+	var impact *WithdrawalImpact
+	err := h.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		impact, err = h.withTx(tx).withdrawParticipant(ctx, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	impact.DryRun = dryRun
+	if !dryRun {
+		for _, w := range impact.Walkovers {
+			log.Printf("[Withdraw] Walkover in %s (%s): %s beats withdrawn team %s", w.Label, w.MatchID, w.WinnerID, w.LoserID)
+		}
+		h.recordAudit(c, "WithdrawParticipant", "participant", before.ID.String(), before, impact)
+	}
+
+	c.JSON(http.StatusOK, impact)
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun/migrate"
+	"badminton_tournament/backend/internal/db/migrations"
+)
+
+// NewMigrator returns a migrator for the versioned migrations of the connected dialect,
+// tracked in the bun_migrations table.
+func NewMigrator() *migrate.Migrator {
+	return migrate.NewMigrator(DB, dialectMigrations(), migrate.WithMarkAppliedOnSuccess(true))
+}
+
+func dialectMigrations() *migrate.Migrations {
+	if IsSQLite(DB) {
+		return migrations.SQLite
+	}
+	return migrations.Migrations
+}
+
+// ErrBaselineRollback is returned by Rollback for the group holding the baseline migration.
+var ErrBaselineRollback = errors.New("the last group contains the baseline migration; rolling it back would drop every tournament table")
+
+// Migrate applies every pending migration as one group. The baseline is applied in a group of
+// its own first, so rolling back later migrations never reaches it. A lock row keeps two
+// instances booting at the same time from migrating concurrently.
+func Migrate(ctx context.Context) error {
+	migrator := NewMigrator()
+	if err := migrator.Init(ctx); err != nil {
+		return fmt.Errorf("init migrations table: %w", err)
+	}
+	if err := migrator.Lock(ctx); err != nil {
+		return fmt.Errorf("lock migrations: %w", err)
+	}
+	defer migrator.Unlock(ctx)
+
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range ms.Unapplied() {
+		if m.Name != migrations.Baseline {
+			continue
+		}
+		baseline := migrate.NewMigrations()
+		baseline.Add(m)
+		group, err := migrate.NewMigrator(DB, baseline, migrate.WithMarkAppliedOnSuccess(true)).Migrate(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Applied migration group #%d: %s", group.ID, group.Migrations)
+	}
+
+	group, err := migrator.Migrate(ctx)
+	if err != nil {
+		return err
+	}
+	if group.IsZero() {
+		log.Println("Database schema is up to date")
+		return nil
+	}
+	log.Printf("Applied migration group #%d: %s", group.ID, group.Migrations)
+	return nil
+}
+
+// Rollback rolls back the most recently applied group. It refuses the group holding the
+// baseline, whose tables may predate versioned migrations and hold real data.
+func Rollback(ctx context.Context) (*migrate.MigrationGroup, error) {
+	migrator := NewMigrator()
+	if err := migrator.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("lock migrations: %w", err)
+	}
+	defer migrator.Unlock(ctx)
+
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	last := ms.LastGroup()
+	for _, m := range last.Migrations {
+		if m.Name == migrations.Baseline {
+			return nil, fmt.Errorf("group #%d: %w", last.ID, ErrBaselineRollback)
+		}
+	}
+	return migrator.Rollback(ctx)
+}
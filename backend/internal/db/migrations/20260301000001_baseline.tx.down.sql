@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS matches;
+DROP TABLE IF EXISTS groups;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS participants;
+DROP TABLE IF EXISTS tournaments;
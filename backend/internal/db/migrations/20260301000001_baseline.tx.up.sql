@@ -0,0 +1,70 @@
+-- Core tournament tables. Written with IF NOT EXISTS so databases created by the old
+-- CreateSchema/cmd/migrate code adopt this migration without changes.
+CREATE TABLE IF NOT EXISTS tournaments (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    name varchar NOT NULL,
+    status varchar NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS participants (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    name varchar NOT NULL,
+    pool varchar NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS categories varchar[],
+    ADD COLUMN IF NOT EXISTS available_dates varchar[],
+    ADD COLUMN IF NOT EXISTS gender varchar,
+    ADD COLUMN IF NOT EXISTS source varchar,
+    ADD COLUMN IF NOT EXISTS status varchar;
+
+CREATE TABLE IF NOT EXISTS teams (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    player1_id uuid NOT NULL,
+    player2_id uuid,
+    name varchar NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS pool varchar NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS category varchar;
+
+CREATE TABLE IF NOT EXISTS groups (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    tournament_id uuid,
+    name varchar NOT NULL,
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS pool varchar NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS category varchar;
+
+CREATE TABLE IF NOT EXISTS matches (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    group_id uuid,
+    label varchar NOT NULL,
+    team_a_id uuid,
+    team_b_id uuid,
+    winner_id uuid,
+    score varchar,
+    video_url varchar,
+    next_match_win_id uuid,
+    next_match_lose_id uuid,
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS sets_detail varchar;
+
+-- The frontend addresses this tournament by its fixed ID
+INSERT INTO tournaments (id, name, status)
+VALUES ('00000000-0000-0000-0000-000000000000', 'Badminton Tournament 2026', 'active')
+ON CONFLICT (id) DO NOTHING;
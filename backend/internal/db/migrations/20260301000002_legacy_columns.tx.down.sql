@@ -0,0 +1,2 @@
+-- sets_detail stays varchar: the JSONB type was never usable by the application
+ALTER TABLE participants ADD COLUMN IF NOT EXISTS partner_request text;
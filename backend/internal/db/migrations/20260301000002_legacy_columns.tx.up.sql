@@ -0,0 +1,15 @@
+-- cmd/migrate_partner_request added this column, which nothing reads
+ALTER TABLE participants DROP COLUMN IF EXISTS partner_request;
+
+-- cmd/migrate created sets_detail as JSONB although the model stores plain text ("21-19, 18-21")
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'matches'
+          AND column_name = 'sets_detail' AND data_type = 'jsonb'
+    ) THEN
+        ALTER TABLE matches ALTER COLUMN sets_detail TYPE varchar USING sets_detail #>> '{}';
+    END IF;
+END
+$$;
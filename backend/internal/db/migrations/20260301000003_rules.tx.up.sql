@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS rules (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    content varchar NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
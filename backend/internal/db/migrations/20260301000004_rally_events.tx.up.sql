@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS rally_events (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    match_id uuid NOT NULL,
+    seq bigint NOT NULL,
+    server varchar NOT NULL,
+    winner varchar NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
+
+-- One rally per sequence number, so concurrent umpire submissions cannot interleave
+CREATE UNIQUE INDEX IF NOT EXISTS rally_events_match_seq_idx ON rally_events (match_id, seq);
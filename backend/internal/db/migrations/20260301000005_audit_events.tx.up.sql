@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    actor varchar NOT NULL,
+    action varchar NOT NULL,
+    entity_type varchar NOT NULL,
+    entity_id varchar,
+    before jsonb,
+    after jsonb,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events (created_at DESC);
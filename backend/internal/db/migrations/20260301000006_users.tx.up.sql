@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS users (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    username varchar NOT NULL,
+    password_hash varchar NOT NULL,
+    role varchar NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id),
+    UNIQUE (username)
+);
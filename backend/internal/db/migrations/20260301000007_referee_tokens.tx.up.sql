@@ -0,0 +1,13 @@
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS court varchar;
+
+CREATE TABLE IF NOT EXISTS referee_tokens (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    label varchar,
+    match_id uuid,
+    court varchar,
+    created_by varchar,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
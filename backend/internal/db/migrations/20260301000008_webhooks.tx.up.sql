@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS webhook_nonces (
+    nonce varchar NOT NULL,
+    source varchar NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (nonce)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    source varchar NOT NULL,
+    idempotency_key varchar NOT NULL,
+    payload varchar NOT NULL,
+    status varchar NOT NULL,
+    error varchar,
+    participant_id uuid,
+    attempts bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    processed_at timestamptz,
+    PRIMARY KEY (id),
+    UNIQUE (idempotency_key)
+);
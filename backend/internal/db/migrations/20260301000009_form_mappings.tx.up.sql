@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS form_mappings (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    source varchar NOT NULL,
+    fields jsonb,
+    value_maps jsonb,
+    updated_at timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id),
+    UNIQUE (source)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE participants ADD COLUMN IF NOT EXISTS external_id varchar;
+
+CREATE UNIQUE INDEX IF NOT EXISTS participants_external_id_idx ON participants (external_id) WHERE external_id IS NOT NULL;
@@ -0,0 +1,45 @@
+// Package migrations holds the versioned schema changes, applied in file name order.
+//
+// Each change is a pair of files named <YYYYMMDDHHMMSS>_<name>.tx.up.sql and
+// <YYYYMMDDHHMMSS>_<name>.tx.down.sql; the ".tx" variants run inside one transaction.
+// Add a new pair for every schema change instead of editing an applied migration.
+//
+// SQLite databases use the files in sqlite/, which carry the same versions and names written
+// in SQLite's dialect. Every schema change needs a pair in both directories.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/uptrace/bun/migrate"
+)
+
+//go:embed *.sql
+var sqlFiles embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+// Baseline is the migration that creates the original tables. Its tables may predate versioned
+// migrations, so it is applied in a group of its own and never rolled back.
+const Baseline = "20260301000001"
+
+// Migrations are the Postgres migrations; SQLite holds their SQLite counterparts.
+var (
+	Migrations = migrate.NewMigrations()
+	SQLite     = migrate.NewMigrations()
+)
+
+func init() {
+	if err := Migrations.Discover(sqlFiles); err != nil {
+		panic(err)
+	}
+	sqliteDir, err := fs.Sub(sqliteFiles, "sqlite")
+	if err != nil {
+		panic(err)
+	}
+	if err := SQLite.Discover(sqliteDir); err != nil {
+		panic(err)
+	}
+}
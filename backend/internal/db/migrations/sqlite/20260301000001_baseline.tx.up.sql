@@ -0,0 +1,64 @@
+-- SQLite has no uuid, array or timestamptz types: UUIDs are stored as text, varchar[] columns
+-- as JSON arrays and timestamps as TIMESTAMP text, which the driver parses back.
+-- gen_random_uuid() is not built in; internal/db/sqlite.go registers it with the driver.
+CREATE TABLE IF NOT EXISTS tournaments (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    name TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS participants (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    name TEXT NOT NULL,
+    pool TEXT NOT NULL,
+    categories TEXT,
+    available_dates TEXT,
+    gender TEXT,
+    source TEXT,
+    status TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    player1_id TEXT NOT NULL,
+    player2_id TEXT,
+    pool TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    category TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS groups (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    tournament_id TEXT,
+    name TEXT NOT NULL,
+    pool TEXT NOT NULL DEFAULT '',
+    category TEXT,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS matches (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    group_id TEXT,
+    label TEXT NOT NULL,
+    team_a_id TEXT,
+    team_b_id TEXT,
+    winner_id TEXT,
+    score TEXT,
+    sets_detail TEXT,
+    video_url TEXT,
+    next_match_win_id TEXT,
+    next_match_lose_id TEXT,
+    PRIMARY KEY (id)
+);
+
+-- The frontend addresses this tournament by its fixed ID
+INSERT INTO tournaments (id, name, status)
+VALUES ('00000000-0000-0000-0000-000000000000', 'Badminton Tournament 2026', 'active')
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,2 @@
+-- Only Postgres databases created before versioned migrations carry legacy columns
+SELECT 1;
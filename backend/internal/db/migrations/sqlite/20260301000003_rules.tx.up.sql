@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS rules (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS rally_events (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    match_id TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    server TEXT NOT NULL,
+    winner TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
+
+-- One rally per sequence number, so concurrent umpire submissions cannot interleave
+CREATE UNIQUE INDEX IF NOT EXISTS rally_events_match_seq_idx ON rally_events (match_id, seq);
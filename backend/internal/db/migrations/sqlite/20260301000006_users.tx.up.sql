@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    username TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id),
+    UNIQUE (username)
+);
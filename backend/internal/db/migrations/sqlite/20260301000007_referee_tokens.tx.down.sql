@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS referee_tokens;
+ALTER TABLE matches DROP COLUMN court;
@@ -0,0 +1,13 @@
+ALTER TABLE matches ADD COLUMN court TEXT;
+
+CREATE TABLE IF NOT EXISTS referee_tokens (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    label TEXT,
+    match_id TEXT,
+    court TEXT,
+    created_by TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id)
+);
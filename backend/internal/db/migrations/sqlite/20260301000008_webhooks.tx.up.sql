@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS webhook_nonces (
+    nonce TEXT NOT NULL,
+    source TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (nonce)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    source TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL,
+    error TEXT,
+    participant_id TEXT,
+    attempts INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    processed_at TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE (idempotency_key)
+);
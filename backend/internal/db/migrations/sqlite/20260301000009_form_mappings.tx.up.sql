@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS form_mappings (
+    id TEXT NOT NULL DEFAULT (gen_random_uuid()),
+    source TEXT NOT NULL,
+    fields TEXT,
+    value_maps TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (id),
+    UNIQUE (source)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS participants_external_id_idx;
+ALTER TABLE participants DROP COLUMN external_id;
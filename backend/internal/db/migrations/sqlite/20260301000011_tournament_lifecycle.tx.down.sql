@@ -0,0 +1 @@
+UPDATE tournaments SET status = 'active' WHERE status IN ('registration', 'groups', 'knockout');
@@ -0,0 +1,7 @@
+-- "active" predates the lifecycle; derive the phase from how far the tournament got
+UPDATE tournaments SET status = CASE
+    WHEN EXISTS (SELECT 1 FROM groups g WHERE g.tournament_id = tournaments.id AND g.name LIKE 'KNOCKOUT%') THEN 'knockout'
+    WHEN EXISTS (SELECT 1 FROM groups g WHERE g.tournament_id = tournaments.id) THEN 'groups'
+    ELSE 'registration'
+END
+WHERE status = 'active';
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"modernc.org/sqlite"
+)
+
+// SQLite runs the whole tournament from a single file, for venues without internet.
+// modernc.org/sqlite is pure Go, so the server still cross-compiles without cgo.
+
+func init() {
+	// The SQLite migrations use gen_random_uuid() as the ID column default, like Postgres,
+	// and bun inlines the models' default:gen_random_uuid() into bulk INSERTs.
+	sqlite.MustRegisterScalarFunction("gen_random_uuid", 0, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return uuid.NewString(), nil
+	})
+}
+
+// sqlitePragmas are applied to every connection: WAL and a busy timeout let the live
+// scoreboard read while a referee writes, and immediate transactions take the write lock up
+// front instead of failing with SQLITE_BUSY halfway through a cascade.
+var sqlitePragmas = []string{
+	"_pragma=foreign_keys(1)",
+	"_pragma=journal_mode(WAL)",
+	"_pragma=busy_timeout(5000)",
+	"_time_format=sqlite",
+	"_txlock=immediate",
+}
+
+// IsSQLiteDSN reports whether DATABASE_URL points at a SQLite file, e.g.
+// "sqlite:///var/lib/badminton/tournament.db", "sqlite://tournament.db" or "file:tournament.db".
+func IsSQLiteDSN(dsn string) bool {
+	return strings.HasPrefix(dsn, "sqlite:") || strings.HasPrefix(dsn, "file:")
+}
+
+// sqliteName turns DATABASE_URL into the file name the driver opens, keeping any query
+// parameters and adding the defaults the caller did not set.
+func sqliteName(dsn string) string {
+	path, query, _ := strings.Cut(dsn, "?")
+	if strings.HasPrefix(path, "sqlite:") {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "sqlite:"), "//")
+	}
+
+	params := []string{}
+	if query != "" {
+		params = append(params, query)
+	}
+	for _, p := range sqlitePragmas {
+		key, _, _ := strings.Cut(p, "=")
+		if strings.HasPrefix(p, "_pragma=") {
+			key, _, _ = strings.Cut(p, "(")
+		}
+		if !strings.Contains(query, key) {
+			params = append(params, p)
+		}
+	}
+	return path + "?" + strings.Join(params, "&")
+}
+
+func connectSQLite(dsn string) error {
+	name := sqliteName(dsn)
+	sqldb, err := sql.Open("sqlite", name)
+	if err != nil {
+		return fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	if strings.Contains(name, ":memory:") {
+		// Every connection to :memory: gets its own empty database
+		sqldb.SetMaxOpenConns(1)
+	}
+
+	DB = bun.NewDB(sqldb, sqlitedialect.New())
+	if err := DB.Ping(); err != nil {
+		return fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	log.Printf("Successfully opened SQLite database %s", strings.SplitN(name, "?", 2)[0])
+	return nil
+}
+
+// IsSQLite reports whether db runs on SQLite, for the few queries that need another spelling.
+func IsSQLite(db bun.IDB) bool {
+	return db.Dialect().Name() == dialect.SQLite
+}
@@ -1,15 +1,35 @@
 package models
 
 import (
-	"strings"
 	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
+var nonAlphanumeric = regexp.MustCompile("[^a-z0-9]+")
+
+// RemoveAccents strips diacritics, e.g. "Nguyễn Văn Đức" -> "Nguyen Van Duc".
+// đ/Đ have no Unicode decomposition and are replaced explicitly.
+func RemoveAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := transform.String(t, s)
+	if err != nil {
+		out = s
+	}
+	return strings.NewReplacer("đ", "d", "Đ", "D").Replace(out)
+}
+
+// NormalizeName is the accent and case insensitive form of a person's name used to detect
+// duplicate registrations: "Nguyễn  Văn A" and "nguyen van a" both become "nguyen van a".
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(RemoveAccents(name))), " ")
+}
+
 func SanitizeNameForEmail(name string) string {
-	// Lowercase
-	s := strings.ToLower(name)
-	// Remove accents (simplistic approach, or just strip non-alphanumeric)
-	reg, _ := regexp.Compile("[^a-z0-9]+")
-	s = reg.ReplaceAllString(s, "")
-	return s
+	// Lowercase, remove accents, then strip everything non-alphanumeric
+	return nonAlphanumeric.ReplaceAllString(NormalizeName(name), "")
 }
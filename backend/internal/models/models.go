@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,15 +13,19 @@ type Tournament struct {
 
 	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
 	Name      string    `bun:"name,notnull" json:"name"`
-	Status    string    `bun:"status,notnull" json:"status"` // 'draft', 'active', 'completed'
+	Status    string    `bun:"status,notnull" json:"status"` // 'draft', 'registration', 'groups', 'knockout', 'completed'
 	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
 }
 
+// ParticipantWithdrawn is the status of a participant who left the tournament.
+const ParticipantWithdrawn = "withdrawn"
+
 type Participant struct {
 	bun.BaseModel `bun:"table:participants,alias:p"`
 
 	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
 	Name      string    `bun:"name,unique,notnull" json:"name"` // Name is now the unique key
+	ExternalID     string    `bun:"external_id,nullzero" json:"external_id,omitempty"` // Email or employee ID; stable across renames
 	Pool           string    `bun:"pool,notnull" json:"pool"` // 'Mesoneer', 'Lab'
 	Categories     []string  `bun:"categories,array" json:"categories"`
 	AvailableDates []string  `bun:"available_dates,array" json:"available_dates"`
@@ -76,6 +81,7 @@ type Match struct {
 	Score    string `bun:"score" json:"score"`         // "21-19, 21-18"
 	SetsDetail string `bun:"sets_detail" json:"sets_detail,omitempty"`
 	VideoURL string `bun:"video_url" json:"video_url"` // YouTube link
+	Court    string `bun:"court" json:"court,omitempty"` // Physical court, used to scope referee tokens
 
 	// Automation Linking
 	NextMatchWinID  uuid.UUID `bun:"next_match_win_id,type:uuid,nullzero" json:"next_match_win_id,omitempty"`
@@ -94,3 +100,97 @@ type Rule struct {
 	Content   string    `bun:"content,notnull" json:"content"`
 	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
 }
+
+// RallyEvent is a single rally recorded by an umpire in live scoring mode.
+// The live score of a match is derived by replaying its events in Seq order.
+type RallyEvent struct {
+	bun.BaseModel `bun:"table:rally_events,alias:re"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	MatchID   uuid.UUID `bun:"match_id,type:uuid,notnull" json:"match_id"`
+	Seq       int       `bun:"seq,notnull" json:"seq"`
+	Server    string    `bun:"server,notnull" json:"server"` // Side serving this rally: "A" or "B"
+	Winner    string    `bun:"winner,notnull" json:"winner"` // Side winning this rally: "A" or "B"
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// AuditEvent records one administrative mutation with the state before and after it.
+type AuditEvent struct {
+	bun.BaseModel `bun:"table:audit_events,alias:ae"`
+
+	ID         uuid.UUID       `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Actor      string          `bun:"actor,notnull" json:"actor"`
+	Action     string          `bun:"action,notnull" json:"action"`           // e.g. "UpdateMatch", "DeleteTeam"
+	EntityType string          `bun:"entity_type,notnull" json:"entity_type"` // "match", "team", "group", "rule"
+	EntityID   string          `bun:"entity_id" json:"entity_id,omitempty"`
+	Before     json.RawMessage `bun:"before,type:jsonb" json:"before,omitempty"`
+	After      json.RawMessage `bun:"after,type:jsonb" json:"after,omitempty"`
+	CreatedAt  time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// User is a named account that can log into the admin dashboard.
+type User struct {
+	bun.BaseModel `bun:"table:users,alias:u"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Username     string    `bun:"username,unique,notnull" json:"username"`
+	PasswordHash string    `bun:"password_hash,notnull" json:"-"` // bcrypt
+	Role         string    `bun:"role,notnull" json:"role"`       // Casbin subject: 'admin', 'referee', 'viewer'
+	CreatedAt    time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt    time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// RefereeToken is a short-lived credential handed to a volunteer referee. It only allows
+// recording results for one match, or for every match assigned to one court.
+type RefereeToken struct {
+	bun.BaseModel `bun:"table:referee_tokens,alias:rt"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Label     string    `bun:"label" json:"label"` // e.g. volunteer name
+	MatchID   uuid.UUID `bun:"match_id,type:uuid,nullzero" json:"match_id,omitempty"`
+	Court     string    `bun:"court" json:"court,omitempty"`
+	CreatedBy string    `bun:"created_by" json:"created_by"`
+	ExpiresAt time.Time `bun:"expires_at,notnull" json:"expires_at"`
+	RevokedAt time.Time `bun:"revoked_at,nullzero" json:"revoked_at,omitempty"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// WebhookNonce remembers nonces of signed webhook deliveries to reject replays.
+type WebhookNonce struct {
+	bun.BaseModel `bun:"table:webhook_nonces,alias:wn"`
+
+	Nonce     string    `bun:"nonce,pk" json:"nonce"`
+	Source    string    `bun:"source,notnull" json:"source"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// WebhookDelivery is the raw payload of an inbound form submission and the outcome of processing it.
+// Failed deliveries stay here (dead letters) until an admin fixes and replays them.
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:wd"`
+
+	ID             uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Source         string    `bun:"source,notnull" json:"source"`
+	IdempotencyKey string    `bun:"idempotency_key,unique,notnull" json:"idempotency_key"`
+	Payload        string    `bun:"payload,notnull" json:"payload"`
+	Status         string    `bun:"status,notnull" json:"status"` // 'processed', 'failed'
+	Error          string    `bun:"error" json:"error,omitempty"`
+	ParticipantID  uuid.UUID `bun:"participant_id,type:uuid,nullzero" json:"participant_id,omitempty"`
+	Attempts       int       `bun:"attempts,notnull" json:"attempts"`
+	CreatedAt      time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	ProcessedAt    time.Time `bun:"processed_at,nullzero" json:"processed_at,omitempty"`
+}
+
+// FormMapping describes how one registration source's payload maps onto a Participant.
+// Fields maps a participant field ("name", "pool", "categories", "available_dates", "gender",
+// "source", "status") to a key in the payload; dotted keys walk nested objects and arrays.
+// ValueMaps translates raw answers per field, e.g. {"gender": {"Nam": "Male", "Nữ": "Female"}}.
+type FormMapping struct {
+	bun.BaseModel `bun:"table:form_mappings,alias:fm"`
+
+	ID        uuid.UUID                    `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Source    string                       `bun:"source,unique,notnull" json:"source"`
+	Fields    map[string]string            `bun:"fields,type:jsonb" json:"fields"`
+	ValueMaps map[string]map[string]string `bun:"value_maps,type:jsonb" json:"value_maps"`
+	UpdatedAt time.Time                    `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
@@ -0,0 +1,378 @@
+// Package memory implements the repositories with maps guarded by a mutex. It is meant for
+// tests, simulations and dry runs; nothing is persisted.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+)
+
+// data is shared by the four repositories of one store. Rows are stored by value and copied
+// on the way in and out, so callers never alias the store's state.
+type data struct {
+	mu           sync.RWMutex
+	tournaments  map[uuid.UUID]models.Tournament
+	participants map[uuid.UUID]models.Participant
+	teams        map[uuid.UUID]models.Team
+	groups       map[uuid.UUID]models.Group
+	matches      map[uuid.UUID]models.Match
+}
+
+// NewStore returns an empty in-memory store.
+func NewStore() *repository.Store {
+	d := &data{
+		tournaments:  make(map[uuid.UUID]models.Tournament),
+		participants: make(map[uuid.UUID]models.Participant),
+		teams:        make(map[uuid.UUID]models.Team),
+		groups:       make(map[uuid.UUID]models.Group),
+		matches:      make(map[uuid.UUID]models.Match),
+	}
+	return &repository.Store{
+		Tournaments:  &tournamentRepo{d},
+		Participants: &participantRepo{d},
+		Teams:        &teamRepo{d},
+		Groups:       &groupRepo{d},
+		Matches:      &matchRepo{d},
+	}
+}
+
+type tournamentRepo struct{ *data }
+
+func (r *tournamentRepo) Get(ctx context.Context, id uuid.UUID) (*models.Tournament, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tournaments[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &t, nil
+}
+
+func (r *tournamentRepo) Create(ctx context.Context, tournament *models.Tournament) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tournament.ID == uuid.Nil {
+		tournament.ID = uuid.New()
+	}
+	if _, ok := r.tournaments[tournament.ID]; ok {
+		return fmt.Errorf("tournament %s already exists", tournament.ID)
+	}
+	if tournament.CreatedAt.IsZero() {
+		tournament.CreatedAt = time.Now()
+	}
+	r.tournaments[tournament.ID] = *tournament
+	return nil
+}
+
+func (r *tournamentRepo) Update(ctx context.Context, tournament *models.Tournament, columns ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.tournaments[tournament.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if len(columns) == 0 {
+		stored = *tournament
+	}
+	for _, col := range columns {
+		switch col {
+		case "name":
+			stored.Name = tournament.Name
+		case "status":
+			stored.Status = tournament.Status
+		}
+	}
+	r.tournaments[tournament.ID] = stored
+	return nil
+}
+
+type participantRepo struct{ *data }
+
+func (r *participantRepo) Get(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.participants[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &p, nil
+}
+
+func (r *participantRepo) List(ctx context.Context, filter repository.ParticipantFilter) ([]models.Participant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []models.Participant
+	for _, p := range r.participants {
+		if filter.Active && p.Status == models.ParticipantWithdrawn {
+			continue
+		}
+		if filter.Pool == "" || p.Pool == filter.Pool {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (r *participantRepo) Create(ctx context.Context, participant *models.Participant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.participants {
+		if p.Name == participant.Name {
+			return fmt.Errorf("participant %q already exists", participant.Name)
+		}
+	}
+	if participant.ID == uuid.Nil {
+		participant.ID = uuid.New()
+	}
+	if participant.CreatedAt.IsZero() {
+		participant.CreatedAt = time.Now()
+	}
+	r.participants[participant.ID] = *participant
+	return nil
+}
+
+type teamRepo struct{ *data }
+
+func (r *teamRepo) Get(ctx context.Context, id uuid.UUID) (*models.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.teams[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &t, nil
+}
+
+func (r *teamRepo) GetMany(ctx context.Context, ids []uuid.UUID) ([]models.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []models.Team
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range ids {
+		if t, ok := r.teams[id]; ok && !seen[id] {
+			seen[id] = true
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (r *teamRepo) List(ctx context.Context, filter repository.TeamFilter) ([]models.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	scheduled := make(map[uuid.UUID]bool)
+	if filter.Unscheduled {
+		for _, m := range r.matches {
+			scheduled[m.TeamAID] = true
+			scheduled[m.TeamBID] = true
+		}
+	}
+	var out []models.Team
+	for _, t := range r.teams {
+		if filter.Pool != "" && t.Pool != filter.Pool {
+			continue
+		}
+		if filter.Category != "" && t.Category != filter.Category {
+			continue
+		}
+		if scheduled[t.ID] {
+			continue
+		}
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+func (r *teamRepo) CreateMany(ctx context.Context, teams []models.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for i := range teams {
+		t := &teams[i]
+		if t.ID == uuid.Nil {
+			t.ID = uuid.New()
+		}
+		if t.CreatedAt.IsZero() {
+			t.CreatedAt = now
+		}
+		t.Player1, t.Player2 = nil, nil
+		r.teams[t.ID] = *t
+	}
+	return nil
+}
+
+type groupRepo struct{ *data }
+
+// withMatches copies g and attaches its matches ordered by label; the caller holds the lock.
+func (r *groupRepo) withMatches(g models.Group) *models.Group {
+	g.Matches = nil
+	for _, m := range r.matches {
+		if m.GroupID == g.ID {
+			m := m
+			g.Matches = append(g.Matches, &m)
+		}
+	}
+	sort.Slice(g.Matches, func(i, j int) bool { return g.Matches[i].Label < g.Matches[j].Label })
+	return &g
+}
+
+func (r *groupRepo) match(g models.Group, filter repository.GroupFilter) bool {
+	if !filter.AllCategories && g.Category != filter.Category {
+		return false
+	}
+	if filter.TournamentID != uuid.Nil && g.TournamentID != filter.TournamentID {
+		return false
+	}
+	return filter.Name == "" || g.Name == filter.Name
+}
+
+func (r *groupRepo) Get(ctx context.Context, id uuid.UUID) (*models.Group, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.groups[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return r.withMatches(g), nil
+}
+
+func (r *groupRepo) Find(ctx context.Context, filter repository.GroupFilter) (*models.Group, error) {
+	groups, err := r.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &groups[0], nil
+}
+
+func (r *groupRepo) List(ctx context.Context, filter repository.GroupFilter) ([]models.Group, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []models.Group
+	for _, g := range r.groups {
+		if r.match(g, filter) {
+			out = append(out, *r.withMatches(g))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (r *groupRepo) Create(ctx context.Context, group *models.Group) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if group.ID == uuid.Nil {
+		group.ID = uuid.New()
+	}
+	g := *group
+	g.Matches = nil
+	r.groups[g.ID] = g
+	return nil
+}
+
+func (r *groupRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for mid, m := range r.matches {
+		if m.GroupID == id {
+			delete(r.matches, mid)
+		}
+	}
+	delete(r.groups, id)
+	return nil
+}
+
+type matchRepo struct{ *data }
+
+func (r *matchRepo) Get(ctx context.Context, id uuid.UUID) (*models.Match, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.matches[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &m, nil
+}
+
+func (r *matchRepo) Create(ctx context.Context, match *models.Match) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if match.ID == uuid.Nil {
+		match.ID = uuid.New()
+	}
+	m := *match
+	m.TeamA, m.TeamB, m.Winner = nil, nil, nil
+	r.matches[m.ID] = m
+	return nil
+}
+
+// Update copies the named columns from match into the stored row, or the whole row if no
+// columns are given, mirroring an UPDATE ... SET of those columns.
+func (r *matchRepo) Update(ctx context.Context, match *models.Match, columns ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.matches[match.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if len(columns) == 0 {
+		stored = *match
+		stored.TeamA, stored.TeamB, stored.Winner = nil, nil, nil
+	}
+	for _, col := range columns {
+		switch col {
+		case "team_a_id":
+			stored.TeamAID = match.TeamAID
+		case "team_b_id":
+			stored.TeamBID = match.TeamBID
+		case "winner_id":
+			stored.WinnerID = match.WinnerID
+		case "score":
+			stored.Score = match.Score
+		case "sets_detail":
+			stored.SetsDetail = match.SetsDetail
+		case "video_url":
+			stored.VideoURL = match.VideoURL
+		case "court":
+			stored.Court = match.Court
+		case "next_match_win_id":
+			stored.NextMatchWinID = match.NextMatchWinID
+		case "next_match_lose_id":
+			stored.NextMatchLoseID = match.NextMatchLoseID
+		}
+	}
+	r.matches[match.ID] = stored
+	return nil
+}
+
+func (r *matchRepo) CountForTeams(ctx context.Context, teamIDs []uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make(map[uuid.UUID]bool, len(teamIDs))
+	for _, id := range teamIDs {
+		ids[id] = true
+	}
+	n := 0
+	for _, m := range r.matches {
+		if (m.TeamAID != uuid.Nil && ids[m.TeamAID]) || (m.TeamBID != uuid.Nil && ids[m.TeamBID]) {
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,230 @@
+// Package postgres implements the repositories with bun on PostgreSQL.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+)
+
+// NewStore returns repositories that run their queries on db, which may be a transaction.
+func NewStore(db bun.IDB) *repository.Store {
+	return &repository.Store{
+		Tournaments:  &tournamentRepo{db},
+		Participants: &participantRepo{db},
+		Teams:        &teamRepo{db},
+		Groups:       &groupRepo{db},
+		Matches:      &matchRepo{db},
+	}
+}
+
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+type tournamentRepo struct{ db bun.IDB }
+
+func (r *tournamentRepo) Get(ctx context.Context, id uuid.UUID) (*models.Tournament, error) {
+	var t models.Tournament
+	if err := r.db.NewSelect().Model(&t).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, notFound(err)
+	}
+	return &t, nil
+}
+
+func (r *tournamentRepo) Create(ctx context.Context, tournament *models.Tournament) error {
+	_, err := r.db.NewInsert().Model(tournament).Returning("*").Exec(ctx)
+	return err
+}
+
+func (r *tournamentRepo) Update(ctx context.Context, tournament *models.Tournament, columns ...string) error {
+	q := r.db.NewUpdate().Model(tournament).WherePK()
+	if len(columns) > 0 {
+		q.Column(columns...)
+	}
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+type participantRepo struct{ db bun.IDB }
+
+func (r *participantRepo) Get(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	var p models.Participant
+	if err := r.db.NewSelect().Model(&p).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, notFound(err)
+	}
+	return &p, nil
+}
+
+func (r *participantRepo) List(ctx context.Context, filter repository.ParticipantFilter) ([]models.Participant, error) {
+	var participants []models.Participant
+	q := r.db.NewSelect().Model(&participants)
+	if filter.Pool != "" {
+		q.Where("pool = ?", filter.Pool)
+	}
+	if filter.Active {
+		q.Where("status IS NULL OR status <> ?", models.ParticipantWithdrawn)
+	}
+	if err := q.Order("name ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return participants, nil
+}
+
+func (r *participantRepo) Create(ctx context.Context, participant *models.Participant) error {
+	_, err := r.db.NewInsert().Model(participant).Returning("*").Exec(ctx)
+	return err
+}
+
+type teamRepo struct{ db bun.IDB }
+
+func (r *teamRepo) Get(ctx context.Context, id uuid.UUID) (*models.Team, error) {
+	var t models.Team
+	if err := r.db.NewSelect().Model(&t).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, notFound(err)
+	}
+	return &t, nil
+}
+
+func (r *teamRepo) GetMany(ctx context.Context, ids []uuid.UUID) ([]models.Team, error) {
+	var teams []models.Team
+	if len(ids) == 0 {
+		return teams, nil
+	}
+	if err := r.db.NewSelect().Model(&teams).Where("id IN (?)", bun.In(ids)).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *teamRepo) List(ctx context.Context, filter repository.TeamFilter) ([]models.Team, error) {
+	var teams []models.Team
+	q := r.db.NewSelect().Model(&teams)
+	if filter.Pool != "" {
+		q.Where("pool = ?", filter.Pool)
+	}
+	if filter.Category != "" {
+		q.Where("category = ?", filter.Category)
+	}
+	if filter.Unscheduled {
+		q.Where("id NOT IN (SELECT team_a_id FROM matches WHERE team_a_id IS NOT NULL UNION SELECT team_b_id FROM matches WHERE team_b_id IS NOT NULL)")
+	}
+	if err := q.Order("created_at ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *teamRepo) CreateMany(ctx context.Context, teams []models.Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+	_, err := r.db.NewInsert().Model(&teams).Returning("*").Exec(ctx)
+	return err
+}
+
+type groupRepo struct{ db bun.IDB }
+
+func (r *groupRepo) query(filter repository.GroupFilter, dest interface{}) *bun.SelectQuery {
+	q := r.db.NewSelect().Model(dest).Relation("Matches")
+	if !filter.AllCategories {
+		q.Where("g.category = ?", filter.Category)
+	}
+	if filter.TournamentID != uuid.Nil {
+		q.Where("g.tournament_id = ?", filter.TournamentID)
+	}
+	if filter.Name != "" {
+		q.Where("g.name = ?", filter.Name)
+	}
+	return q
+}
+
+func (r *groupRepo) Get(ctx context.Context, id uuid.UUID) (*models.Group, error) {
+	var g models.Group
+	if err := r.db.NewSelect().Model(&g).Relation("Matches").Where("g.id = ?", id).Scan(ctx); err != nil {
+		return nil, notFound(err)
+	}
+	return &g, nil
+}
+
+func (r *groupRepo) Find(ctx context.Context, filter repository.GroupFilter) (*models.Group, error) {
+	var g models.Group
+	if err := r.query(filter, &g).Limit(1).Scan(ctx); err != nil {
+		return nil, notFound(err)
+	}
+	return &g, nil
+}
+
+func (r *groupRepo) List(ctx context.Context, filter repository.GroupFilter) ([]models.Group, error) {
+	var groups []models.Group
+	if err := r.query(filter, &groups).Order("g.name ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *groupRepo) Create(ctx context.Context, group *models.Group) error {
+	_, err := r.db.NewInsert().Model(group).Returning("*").Exec(ctx)
+	return err
+}
+
+func (r *groupRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.NewDelete().Model((*models.Match)(nil)).Where("group_id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+	_, err := r.db.NewDelete().Model((*models.Group)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+type matchRepo struct{ db bun.IDB }
+
+func (r *matchRepo) Get(ctx context.Context, id uuid.UUID) (*models.Match, error) {
+	var m models.Match
+	if err := r.db.NewSelect().Model(&m).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, notFound(err)
+	}
+	return &m, nil
+}
+
+func (r *matchRepo) Create(ctx context.Context, match *models.Match) error {
+	_, err := r.db.NewInsert().Model(match).Returning("*").Exec(ctx)
+	return err
+}
+
+func (r *matchRepo) Update(ctx context.Context, match *models.Match, columns ...string) error {
+	q := r.db.NewUpdate().Model(match).WherePK()
+	if len(columns) > 0 {
+		q.Column(columns...)
+	}
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *matchRepo) CountForTeams(ctx context.Context, teamIDs []uuid.UUID) (int, error) {
+	if len(teamIDs) == 0 {
+		return 0, nil
+	}
+	return r.db.NewSelect().Model((*models.Match)(nil)).
+		Where("team_a_id IN (?) OR team_b_id IN (?)", bun.In(teamIDs), bun.In(teamIDs)).
+		Count(ctx)
+}
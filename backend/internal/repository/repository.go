@@ -0,0 +1,84 @@
+// Package repository defines the storage interfaces the tournament logic depends on.
+// postgres implements them with bun; memory implements them in process for tests and tools.
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+)
+
+// ErrNotFound is returned by Get/Find methods when no row matches.
+var ErrNotFound = errors.New("not found")
+
+type TournamentRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.Tournament, error)
+	Create(ctx context.Context, tournament *models.Tournament) error
+	// Update writes the given columns (all columns if none are given).
+	Update(ctx context.Context, tournament *models.Tournament, columns ...string) error
+}
+
+type ParticipantFilter struct {
+	Pool   string // "" for all pools
+	Active bool   // skip withdrawn participants
+}
+
+type ParticipantRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.Participant, error)
+	List(ctx context.Context, filter ParticipantFilter) ([]models.Participant, error)
+	Create(ctx context.Context, participant *models.Participant) error
+}
+
+type TeamFilter struct {
+	Pool        string // "" for all pools
+	Category    string // "" for all categories
+	Unscheduled bool   // only teams that do not appear in any match yet
+}
+
+type TeamRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.Team, error)
+	GetMany(ctx context.Context, ids []uuid.UUID) ([]models.Team, error)
+	List(ctx context.Context, filter TeamFilter) ([]models.Team, error)
+	// CreateMany inserts the teams and fills in their generated IDs.
+	CreateMany(ctx context.Context, teams []models.Team) error
+}
+
+// GroupFilter selects groups. Category matches exactly unless AllCategories is set, since
+// knockout stages of the uncategorised tournament use "".
+type GroupFilter struct {
+	TournamentID  uuid.UUID // uuid.Nil for all tournaments
+	Name          string    // "" for all names
+	Category      string
+	AllCategories bool
+}
+
+type GroupRepository interface {
+	// Get and Find return the group with its Matches loaded.
+	Get(ctx context.Context, id uuid.UUID) (*models.Group, error)
+	Find(ctx context.Context, filter GroupFilter) (*models.Group, error)
+	// List returns matching groups with their Matches, ordered by name.
+	List(ctx context.Context, filter GroupFilter) ([]models.Group, error)
+	Create(ctx context.Context, group *models.Group) error
+	// Delete removes the group together with its matches.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type MatchRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.Match, error)
+	Create(ctx context.Context, match *models.Match) error
+	// Update writes the given columns (all columns if none are given).
+	Update(ctx context.Context, match *models.Match, columns ...string) error
+	// CountForTeams counts matches in which any of the teams plays.
+	CountForTeams(ctx context.Context, teamIDs []uuid.UUID) (int, error)
+}
+
+// Store bundles one implementation of every repository, sharing a connection or transaction.
+type Store struct {
+	Tournaments  TournamentRepository
+	Participants ParticipantRepository
+	Teams        TeamRepository
+	Groups       GroupRepository
+	Matches      MatchRepository
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+)
+
+type CreateGroupInput struct {
+	Name         string
+	Pool         string
+	TournamentID uuid.UUID
+	TeamIDs      []uuid.UUID
+	Category     string
+}
+
+// CreateGroup validates the four teams, seeds them randomly and creates the group with its
+// five GSL matches. The returned slice is the seeded team order.
+func (s *Tournament) CreateGroup(ctx context.Context, in CreateGroupInput) (*models.Group, []uuid.UUID, error) {
+	if len(in.TeamIDs) != 4 {
+		return nil, nil, ValidationError("Group must have exactly 4 teams")
+	}
+	if in.Pool == "" {
+		return nil, nil, ValidationError("Pool is required (Mesoneer or Lab)")
+	}
+
+	// Teams must be in the same pool and category, and not busy
+	teams, err := s.Store.Teams.GetMany(ctx, in.TeamIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to fetch teams")
+	}
+	if len(teams) != 4 {
+		return nil, nil, ValidationError("One or more teams not found")
+	}
+	for _, team := range teams {
+		if team.Pool != in.Pool {
+			return nil, nil, ValidationError("All teams must belong to the selected Pool (" + in.Pool + ")")
+		}
+	}
+	for _, team := range teams {
+		if team.Category != in.Category {
+			return nil, nil, ValidationError("All teams must belong to the selected Category (" + in.Category + ")")
+		}
+	}
+
+	count, err := s.Store.Matches.CountForTeams(ctx, in.TeamIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to validate team availability")
+	}
+	if count > 0 {
+		return nil, nil, ValidationError("One or more selected teams are already competing in another group in this category")
+	}
+
+	seeded := append([]uuid.UUID(nil), in.TeamIDs...)
+	s.Shuffle(len(seeded), func(i, j int) {
+		seeded[i], seeded[j] = seeded[j], seeded[i]
+	})
+
+	group := &models.Group{
+		TournamentID: in.TournamentID,
+		Name:         in.Name,
+		Pool:         in.Pool,
+		Category:     in.Category,
+	}
+	if err := s.Store.Groups.Create(ctx, group); err != nil {
+		return nil, nil, fmt.Errorf("Failed to create group: %w", err)
+	}
+	if err := s.createGSLMatches(ctx, group.ID, seeded); err != nil {
+		return nil, nil, fmt.Errorf("Failed to create matches: %w", err)
+	}
+	return group, seeded, nil
+}
+
+type AutoGenerateGroupsInput struct {
+	Pool         string
+	TournamentID uuid.UUID
+	NamePrefix   string
+	Category     string
+}
+
+// AutoGenerateGroups shuffles every unscheduled team of a pool and category into groups of four.
+func (s *Tournament) AutoGenerateGroups(ctx context.Context, in AutoGenerateGroupsInput) ([]uuid.UUID, error) {
+	if in.Pool == "" {
+		return nil, ValidationError("Pool is required")
+	}
+
+	available, err := s.Store.Teams.List(ctx, repository.TeamFilter{Pool: in.Pool, Category: in.Category, Unscheduled: true})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch available teams: %w", err)
+	}
+
+	numTeams := len(available)
+	if numTeams == 0 {
+		return nil, ValidationError("No available teams in " + in.Pool + " pool")
+	}
+	if numTeams%4 != 0 {
+		return nil, ValidationError(fmt.Sprintf("Cannot auto-generate: %d teams available, but groups must have exactly 4 teams", numTeams))
+	}
+
+	s.Shuffle(numTeams, func(i, j int) {
+		available[i], available[j] = available[j], available[i]
+	})
+
+	prefix := in.NamePrefix
+	if prefix == "" {
+		prefix = "Group"
+	}
+
+	var created []uuid.UUID
+	for i := 0; i < numTeams; i += 4 {
+		name := fmt.Sprintf("%s %d", prefix, (i/4)+1)
+		group := &models.Group{
+			TournamentID: in.TournamentID,
+			Name:         name,
+			Pool:         in.Pool,
+			Category:     in.Category,
+		}
+		if err := s.Store.Groups.Create(ctx, group); err != nil {
+			return nil, fmt.Errorf("Failed to create group: %w", err)
+		}
+
+		teamIDs := []uuid.UUID{available[i].ID, available[i+1].ID, available[i+2].ID, available[i+3].ID}
+		if err := s.createGSLMatches(ctx, group.ID, teamIDs); err != nil {
+			return nil, fmt.Errorf("Failed to create matches for group %s: %w", name, err)
+		}
+		created = append(created, group.ID)
+	}
+	return created, nil
+}
+
+// createGSLMatches builds the GSL flow back to front so each match can point at the next:
+// M1/M2 winners meet in Winners (M3), losers in Losers (M4), and the Decider (M5) takes the
+// loser of M3 and the winner of M4.
+func (s *Tournament) createGSLMatches(ctx context.Context, groupID uuid.UUID, teamIDs []uuid.UUID) error {
+	m5 := &models.Match{GroupID: groupID, Label: "Decider"}
+	if err := s.Store.Matches.Create(ctx, m5); err != nil {
+		return err
+	}
+	m3 := &models.Match{GroupID: groupID, Label: "Winners", NextMatchLoseID: m5.ID}
+	if err := s.Store.Matches.Create(ctx, m3); err != nil {
+		return err
+	}
+	m4 := &models.Match{GroupID: groupID, Label: "Losers", NextMatchWinID: m5.ID}
+	if err := s.Store.Matches.Create(ctx, m4); err != nil {
+		return err
+	}
+
+	m1 := &models.Match{
+		GroupID:         groupID,
+		Label:           "M1",
+		TeamAID:         teamIDs[0],
+		TeamBID:         teamIDs[1],
+		NextMatchWinID:  m3.ID,
+		NextMatchLoseID: m4.ID,
+	}
+	if err := s.Store.Matches.Create(ctx, m1); err != nil {
+		return err
+	}
+	m2 := &models.Match{
+		GroupID:         groupID,
+		Label:           "M2",
+		TeamAID:         teamIDs[2],
+		TeamBID:         teamIDs[3],
+		NextMatchWinID:  m3.ID,
+		NextMatchLoseID: m4.ID,
+	}
+	return s.Store.Matches.Create(ctx, m2)
+}
@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Integrity issue kinds.
+const (
+	IssueDanglingNextMatch = "dangling_next_match" // NextMatchWinID/NextMatchLoseID points at no match
+	IssueInvalidWinner     = "invalid_winner"      // winner is neither of the match's teams
+	IssueTeamInTwoGroups   = "team_in_two_groups"  // a team plays in two groups of one category
+	IssueDuplicateKnockout = "duplicate_knockout"  // more than one knockout stage per tournament and category
+	IssueSlotWithoutResult = "slot_without_result" // slot filled although the match feeding it is undecided
+	IssueSlotMismatch      = "slot_mismatch"       // slot holds another team than the feeding result sends
+	IssueSlotNotPropagated = "slot_not_propagated" // feeding match decided but its team never arrived
+)
+
+// IntegrityIssue is one problem found in the bracket. Repairable issues have a fix that
+// cannot lose a recorded result; the others need an admin to decide.
+type IntegrityIssue struct {
+	Kind       string    `json:"kind"`
+	GroupID    uuid.UUID `json:"group_id,omitempty"`
+	Group      string    `json:"group,omitempty"`
+	MatchID    uuid.UUID `json:"match_id,omitempty"`
+	Match      string    `json:"match,omitempty"`
+	Message    string    `json:"message"`
+	Repairable bool      `json:"repairable"`
+	Repaired   bool      `json:"repaired"`
+
+	repair func(ctx context.Context) error
+}
+
+type IntegrityReport struct {
+	Groups  int              `json:"groups"`
+	Matches int              `json:"matches"`
+	Issues  []IntegrityIssue `json:"issues"`
+}
+
+// Repairable counts the issues a repair run would fix.
+func (r *IntegrityReport) Repairable() int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Repairable && !issue.Repaired {
+			n++
+		}
+	}
+	return n
+}
+
+func isKnockout(g *models.Group) bool {
+	return strings.HasPrefix(g.Name, "KNOCKOUT")
+}
+
+func matchByLabel(g *models.Group, label string) *models.Match {
+	for _, m := range g.Matches {
+		if m.Label == label {
+			return m
+		}
+	}
+	return nil
+}
+
+// integrityCheck holds the loaded bracket while the checks run.
+type integrityCheck struct {
+	s       *Tournament
+	groups  []models.Group
+	groupOf map[uuid.UUID]*models.Group // match ID -> group
+	teams   map[uuid.UUID]string
+	report  *IntegrityReport
+}
+
+func (c *integrityCheck) teamName(id uuid.UUID) string {
+	if name, ok := c.teams[id]; ok {
+		return fmt.Sprintf("%q", name)
+	}
+	return id.String()
+}
+
+func (c *integrityCheck) add(kind string, g *models.Group, m *models.Match, repair func(ctx context.Context) error, format string, args ...interface{}) {
+	issue := IntegrityIssue{Kind: kind, Message: fmt.Sprintf(format, args...), Repairable: repair != nil, repair: repair}
+	if g != nil {
+		issue.GroupID, issue.Group = g.ID, g.Name
+	}
+	if m != nil {
+		issue.MatchID, issue.Match = m.ID, m.Label
+	}
+	c.report.Issues = append(c.report.Issues, issue)
+}
+
+// CheckIntegrity inspects every group and match. With repair set, the repairable issues are
+// fixed; run it in a transaction so a failed repair leaves nothing half done.
+func (s *Tournament) CheckIntegrity(ctx context.Context, repair bool) (*IntegrityReport, error) {
+	groups, err := s.Store.Groups.List(ctx, repository.GroupFilter{AllCategories: true})
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.Store.Teams.List(ctx, repository.TeamFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	c := &integrityCheck{
+		s:       s,
+		groups:  groups,
+		groupOf: make(map[uuid.UUID]*models.Group),
+		teams:   make(map[uuid.UUID]string, len(teams)),
+		report:  &IntegrityReport{Groups: len(groups), Issues: []IntegrityIssue{}},
+	}
+	for _, t := range teams {
+		c.teams[t.ID] = t.Name
+	}
+	for i := range groups {
+		for _, m := range groups[i].Matches {
+			c.groupOf[m.ID] = &groups[i]
+			c.report.Matches++
+		}
+	}
+
+	if err := c.checkNextMatches(ctx); err != nil {
+		return nil, err
+	}
+	c.checkWinners()
+	c.checkTeamsInTwoGroups()
+	knockouts := c.checkDuplicateKnockouts()
+	c.checkSlots(knockouts)
+
+	if repair {
+		for i := range c.report.Issues {
+			issue := &c.report.Issues[i]
+			if issue.repair == nil {
+				continue
+			}
+			if err := issue.repair(ctx); err != nil {
+				return nil, fmt.Errorf("repair %s (%s): %w", issue.Kind, issue.Message, err)
+			}
+			issue.Repaired = true
+		}
+	}
+	return c.report, nil
+}
+
+func (c *integrityCheck) checkNextMatches(ctx context.Context) error {
+	for i := range c.groups {
+		g := &c.groups[i]
+		for _, m := range g.Matches {
+			m := m
+			for _, col := range []string{"next_match_win_id", "next_match_lose_id"} {
+				target := m.NextMatchWinID
+				if col == "next_match_lose_id" {
+					target = m.NextMatchLoseID
+				}
+				if target == uuid.Nil {
+					continue
+				}
+				if _, ok := c.groupOf[target]; ok {
+					continue
+				}
+				if _, err := c.s.Store.Matches.Get(ctx, target); err == nil {
+					continue
+				} else if !errors.Is(err, repository.ErrNotFound) {
+					return err
+				}
+
+				col := col
+				c.add(IssueDanglingNextMatch, g, m, func(ctx context.Context) error {
+					if col == "next_match_win_id" {
+						m.NextMatchWinID = uuid.Nil
+					} else {
+						m.NextMatchLoseID = uuid.Nil
+					}
+					return c.s.Store.Matches.Update(ctx, m, col)
+				}, "%s %s points at match %s, which does not exist", g.Name, col, target)
+			}
+		}
+	}
+	return nil
+}
+
+// validWinner reports whether the match is undecided or won by one of its teams.
+func validWinner(m *models.Match) bool {
+	return m.WinnerID == uuid.Nil || m.WinnerID == m.TeamAID || m.WinnerID == m.TeamBID
+}
+
+func (c *integrityCheck) checkWinners() {
+	for i := range c.groups {
+		g := &c.groups[i]
+		for _, m := range g.Matches {
+			if !validWinner(m) {
+				c.add(IssueInvalidWinner, g, m, nil, "winner %s is not one of the teams (%s vs %s)",
+					c.teamName(m.WinnerID), c.teamName(m.TeamAID), c.teamName(m.TeamBID))
+			}
+		}
+	}
+}
+
+func (c *integrityCheck) checkTeamsInTwoGroups() {
+	type key struct {
+		category string
+		team     uuid.UUID
+	}
+	seen := make(map[key]*models.Group)
+	for i := range c.groups {
+		g := &c.groups[i]
+		if isKnockout(g) {
+			continue
+		}
+		inGroup := make(map[uuid.UUID]bool)
+		for _, m := range g.Matches {
+			inGroup[m.TeamAID], inGroup[m.TeamBID] = true, true
+		}
+		delete(inGroup, uuid.Nil)
+		for team := range inGroup {
+			k := key{g.Category, team}
+			if other, ok := seen[k]; ok {
+				c.add(IssueTeamInTwoGroups, g, nil, nil, "team %s plays in both %s and %s", c.teamName(team), other.Name, g.Name)
+				continue
+			}
+			seen[k] = g
+		}
+	}
+}
+
+// filledSlots counts the teams and winners entered in a group, to tell an unused knockout
+// stage from one in play.
+func filledSlots(g *models.Group) int {
+	n := 0
+	for _, m := range g.Matches {
+		for _, id := range []uuid.UUID{m.TeamAID, m.TeamBID, m.WinnerID} {
+			if id != uuid.Nil {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+type stageKey struct {
+	tournament uuid.UUID
+	category   string
+}
+
+// checkDuplicateKnockouts keeps the knockout stage with the most progress per tournament and
+// category and returns those; empty duplicates can be deleted safely.
+func (c *integrityCheck) checkDuplicateKnockouts() map[stageKey]*models.Group {
+	stages := make(map[stageKey][]*models.Group)
+	var keys []stageKey
+	for i := range c.groups {
+		g := &c.groups[i]
+		if !isKnockout(g) {
+			continue
+		}
+		k := stageKey{g.TournamentID, g.Category}
+		if _, ok := stages[k]; !ok {
+			keys = append(keys, k)
+		}
+		stages[k] = append(stages[k], g)
+	}
+
+	kept := make(map[stageKey]*models.Group)
+	for _, k := range keys {
+		gs := stages[k]
+		keep := gs[0]
+		for _, g := range gs[1:] {
+			if filledSlots(g) > filledSlots(keep) {
+				keep = g
+			}
+		}
+		kept[k] = keep
+
+		for _, g := range gs {
+			if g == keep {
+				continue
+			}
+			g := g
+			var repair func(ctx context.Context) error
+			if filledSlots(g) == 0 {
+				repair = func(ctx context.Context) error { return c.s.Store.Groups.Delete(ctx, g.ID) }
+			}
+			c.add(IssueDuplicateKnockout, g, nil, repair, "duplicate knockout stage for category %q; %s (%s) is kept", k.category, keep.Name, keep.ID)
+		}
+	}
+	return kept
+}
+
+// feeder is the result that decides who takes a slot: the winner or loser of a match.
+type feeder struct {
+	match *models.Match
+	group *models.Group
+	loser bool
+}
+
+// team returns the team the feeder sends, or uuid.Nil while the match is undecided.
+func (f feeder) team() uuid.UUID {
+	m := f.match
+	if m.WinnerID == uuid.Nil || !f.loser {
+		return m.WinnerID
+	}
+	if m.WinnerID == m.TeamAID {
+		return m.TeamBID
+	}
+	return m.TeamAID
+}
+
+func (f feeder) String() string {
+	outcome := "winner"
+	if f.loser {
+		outcome = "loser"
+	}
+	return fmt.Sprintf("%s of %s %s", outcome, f.group.Name, f.match.Label)
+}
+
+type slot struct {
+	match uuid.UUID
+	col   string
+}
+
+// checkSlots compares every team slot with the result feeding it, following the same rules
+// as PropagateResult. Slots with more than one possible feeder (several groups per pool) are
+// skipped.
+func (c *integrityCheck) checkSlots(knockouts map[stageKey]*models.Group) {
+	feeders := make(map[slot][]feeder)
+
+	for i := range c.groups {
+		g := &c.groups[i]
+		for _, m := range g.Matches {
+			if !validWinner(m) {
+				continue // already reported; the team it sends is unknown
+			}
+			// Winners and Decider winners are promoted to the knockout stage, not along NextMatchWinID
+			if m.NextMatchWinID != uuid.Nil && m.Label != "Winners" && m.Label != "Decider" {
+				if target, ok := c.matchByID(m.NextMatchWinID); ok {
+					if col := routeSlot(target.Label, m.Label); col != "" {
+						feeders[slot{target.ID, col}] = append(feeders[slot{target.ID, col}], feeder{m, g, false})
+					}
+				}
+			}
+			if m.NextMatchLoseID != uuid.Nil {
+				if target, ok := c.matchByID(m.NextMatchLoseID); ok {
+					if col := routeSlot(target.Label, m.Label); col != "" {
+						feeders[slot{target.ID, col}] = append(feeders[slot{target.ID, col}], feeder{m, g, true})
+					}
+				}
+			}
+		}
+
+		if isKnockout(g) {
+			continue
+		}
+		ko, ok := knockouts[stageKey{g.TournamentID, g.Category}]
+		if !ok {
+			continue
+		}
+		for rank, label := range []string{"Winners", "Decider"} {
+			m := matchByLabel(g, label)
+			sfLabel, col := crossOverSlot(g.Pool, rank+1)
+			sf := matchByLabel(ko, sfLabel)
+			if m == nil || sf == nil || !validWinner(m) {
+				continue
+			}
+			feeders[slot{sf.ID, col}] = append(feeders[slot{sf.ID, col}], feeder{m, g, false})
+		}
+	}
+
+	for i := range c.groups {
+		g := &c.groups[i]
+		if isKnockout(g) && knockouts[stageKey{g.TournamentID, g.Category}] != g {
+			continue // duplicate stage, reported above
+		}
+		for _, m := range g.Matches {
+			for _, col := range []string{"team_a_id", "team_b_id"} {
+				fs := feeders[slot{m.ID, col}]
+				if len(fs) != 1 {
+					continue
+				}
+				c.checkSlot(g, m, col, fs[0])
+			}
+		}
+	}
+}
+
+func (c *integrityCheck) matchByID(id uuid.UUID) (*models.Match, bool) {
+	g, ok := c.groupOf[id]
+	if !ok {
+		return nil, false
+	}
+	for _, m := range g.Matches {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+func (c *integrityCheck) checkSlot(g *models.Group, m *models.Match, col string, f feeder) {
+	actual := m.TeamAID
+	if col == "team_b_id" {
+		actual = m.TeamBID
+	}
+	expected := f.team()
+	if actual == expected {
+		return
+	}
+
+	// Changing the teams of a decided match would orphan its result
+	set := func(team uuid.UUID) func(ctx context.Context) error {
+		if m.WinnerID != uuid.Nil {
+			return nil
+		}
+		return func(ctx context.Context) error {
+			setSlot(m, col, team)
+			return c.s.Store.Matches.Update(ctx, m, col)
+		}
+	}
+
+	switch {
+	case expected == uuid.Nil:
+		c.add(IssueSlotWithoutResult, g, m, set(uuid.Nil), "%s holds %s but the %s is not decided yet",
+			col, c.teamName(actual), f)
+	case actual == uuid.Nil:
+		c.add(IssueSlotNotPropagated, g, m, set(expected), "%s is empty but the %s is %s",
+			col, f, c.teamName(expected))
+	default:
+		c.add(IssueSlotMismatch, g, m, set(expected), "%s holds %s but the %s is %s",
+			col, c.teamName(actual), f, c.teamName(expected))
+	}
+}
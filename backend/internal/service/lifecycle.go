@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+)
+
+// Tournament phases, in order. Status holds one of these.
+const (
+	PhaseDraft        = "draft"
+	PhaseRegistration = "registration"
+	PhaseGroups       = "groups"
+	PhaseKnockout     = "knockout"
+	PhaseCompleted    = "completed"
+)
+
+// phaseTransitions lists the phases reachable from each phase. Going back is only possible
+// before anything has been played.
+var phaseTransitions = map[string][]string{
+	PhaseDraft:        {PhaseRegistration},
+	PhaseRegistration: {PhaseDraft, PhaseGroups},
+	PhaseGroups:       {PhaseRegistration, PhaseKnockout},
+	PhaseKnockout:     {PhaseCompleted},
+	PhaseCompleted:    {},
+}
+
+// Operation is a kind of change that is only allowed in some phases.
+type Operation string
+
+const (
+	OpRegister        Operation = "register participants" // new registrations, imports, merges and deletions
+	OpEditParticipant Operation = "edit participants"
+	OpEditTeams       Operation = "edit teams"
+	OpCreateGroups    Operation = "create groups"
+	OpKnockout        Operation = "generate the knockout stage"
+	OpPlay            Operation = "record results"
+	OpWithdraw        Operation = "withdraw participants"
+)
+
+var allowedPhases = map[Operation][]string{
+	OpRegister:        {PhaseDraft, PhaseRegistration},
+	OpEditParticipant: {PhaseDraft, PhaseRegistration, PhaseGroups, PhaseKnockout},
+	OpEditTeams:       {PhaseDraft, PhaseRegistration, PhaseGroups},
+	OpCreateGroups:    {PhaseGroups},
+	OpKnockout:        {PhaseGroups, PhaseKnockout},
+	OpPlay:            {PhaseGroups, PhaseKnockout},
+	OpWithdraw:        {PhaseRegistration, PhaseGroups, PhaseKnockout},
+}
+
+// PhaseError is returned when the tournament's phase does not allow an operation; handlers
+// report it as a 409.
+type PhaseError struct {
+	Op      Operation
+	Phase   string
+	Allowed []string
+}
+
+func (e *PhaseError) Error() string {
+	return fmt.Sprintf("cannot %s while the tournament is in the %s phase (allowed in: %s)",
+		e.Op, e.Phase, strings.Join(e.Allowed, ", "))
+}
+
+// Phase returns the tournament's lifecycle phase. Tournaments created before the lifecycle
+// existed are "active", which meant play was under way.
+func Phase(t *models.Tournament) string {
+	if t.Status == "active" {
+		return PhaseGroups
+	}
+	return t.Status
+}
+
+// NextPhases lists the phases the tournament can move to.
+func NextPhases(t *models.Tournament) []string {
+	return phaseTransitions[Phase(t)]
+}
+
+// CheckPhase returns a *PhaseError unless the tournament's phase allows op.
+func (s *Tournament) CheckPhase(ctx context.Context, tournamentID uuid.UUID, op Operation) error {
+	t, err := s.Store.Tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	phase := Phase(t)
+	for _, p := range allowedPhases[op] {
+		if p == phase {
+			return nil
+		}
+	}
+	return &PhaseError{Op: op, Phase: phase, Allowed: allowedPhases[op]}
+}
+
+// CheckMatchPhase is CheckPhase for the tournament the match belongs to.
+func (s *Tournament) CheckMatchPhase(ctx context.Context, matchID uuid.UUID, op Operation) error {
+	match, err := s.Store.Matches.Get(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	group, err := s.Store.Groups.Get(ctx, match.GroupID)
+	if err != nil {
+		return err
+	}
+	return s.CheckPhase(ctx, group.TournamentID, op)
+}
+
+// Transition moves the tournament to another phase after checking that the move is allowed
+// and that the current phase is finished.
+func (s *Tournament) Transition(ctx context.Context, tournamentID uuid.UUID, to string) (before, after *models.Tournament, err error) {
+	t, err := s.Store.Tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	before = &models.Tournament{}
+	*before = *t
+
+	from := Phase(t)
+	allowed := false
+	for _, p := range phaseTransitions[from] {
+		if p == to {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, nil, ValidationError(fmt.Sprintf("Cannot move the tournament from %s to %s", from, to))
+	}
+
+	if err := s.checkTransition(ctx, tournamentID, from, to); err != nil {
+		return nil, nil, err
+	}
+
+	t.Status = to
+	if err := s.Store.Tournaments.Update(ctx, t, "status"); err != nil {
+		return nil, nil, err
+	}
+	return before, t, nil
+}
+
+func (s *Tournament) checkTransition(ctx context.Context, tournamentID uuid.UUID, from, to string) error {
+	if (from == PhaseGroups && to == PhaseRegistration) || to == PhaseKnockout || to == PhaseCompleted {
+		all, err := s.Store.Groups.List(ctx, repository.GroupFilter{TournamentID: tournamentID, AllCategories: true})
+		if err != nil {
+			return err
+		}
+		var groups, knockouts []models.Group
+		for _, g := range all {
+			// uuid.Nil is both the default tournament and the "any tournament" filter
+			if g.TournamentID != tournamentID {
+				continue
+			}
+			if strings.HasPrefix(g.Name, "KNOCKOUT") {
+				knockouts = append(knockouts, g)
+			} else {
+				groups = append(groups, g)
+			}
+		}
+
+		switch to {
+		case PhaseRegistration:
+			if len(groups) > 0 {
+				return ValidationError("Cannot reopen registration: groups have already been drawn")
+			}
+		case PhaseKnockout:
+			if len(groups) == 0 {
+				return ValidationError("Cannot start the knockout stage: no groups have been drawn")
+			}
+			if label := firstUndecided(groups); label != "" {
+				return ValidationError(fmt.Sprintf("Cannot start the knockout stage: %s has not been played", label))
+			}
+		case PhaseCompleted:
+			if len(knockouts) == 0 {
+				return ValidationError("Cannot complete the tournament: there is no knockout stage")
+			}
+			if label := firstUndecided(knockouts); label != "" {
+				return ValidationError(fmt.Sprintf("Cannot complete the tournament: %s has not been played", label))
+			}
+		}
+	}
+	return nil
+}
+
+// firstUndecided names the first match without a winner, or returns "".
+func firstUndecided(groups []models.Group) string {
+	for _, g := range groups {
+		for _, m := range g.Matches {
+			if m.WinnerID == uuid.Nil {
+				return g.Name + " " + m.Label
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+)
+
+func IsMale(g string) bool {
+	g = strings.TrimSpace(strings.ToLower(g))
+	return g == "male" || g == "man" || g == "m" || g == "nam"
+}
+
+func IsFemale(g string) bool {
+	g = strings.TrimSpace(strings.ToLower(g))
+	return g == "female" || g == "woman" || g == "f" || g == "w" || g == "nữ" || g == "nu"
+}
+
+// PairTeams pairs the participants that are not yet in a team of the category, within their
+// pool: two men for MensDoubles, one man and one woman for MixedDoubles. Other categories
+// produce no teams.
+func PairTeams(participants []models.Participant, existing []models.Team, category string, shuffle func(n int, swap func(i, j int))) []models.Team {
+	busy := make(map[uuid.UUID]bool)
+	for _, t := range existing {
+		busy[t.Player1ID] = true
+		busy[t.Player2ID] = true
+	}
+
+	type poolGender struct {
+		males, females []models.Participant
+	}
+	var pools []string
+	free := make(map[string]*poolGender)
+	for _, p := range participants {
+		if busy[p.ID] {
+			continue
+		}
+		if _, ok := free[p.Pool]; !ok {
+			free[p.Pool] = &poolGender{}
+			pools = append(pools, p.Pool)
+		}
+		if IsMale(p.Gender) {
+			free[p.Pool].males = append(free[p.Pool].males, p)
+		} else if IsFemale(p.Gender) {
+			free[p.Pool].females = append(free[p.Pool].females, p)
+		}
+	}
+
+	shuffleParticipants := func(ps []models.Participant) {
+		shuffle(len(ps), func(i, j int) { ps[i], ps[j] = ps[j], ps[i] })
+	}
+	team := func(pool string, p1, p2 models.Participant) models.Team {
+		return models.Team{
+			Player1ID: p1.ID,
+			Player2ID: p2.ID,
+			Pool:      pool,
+			Name:      p1.Name + " & " + p2.Name,
+			Category:  category,
+		}
+	}
+
+	var teams []models.Team
+	for _, pool := range pools {
+		data := free[pool]
+		switch category {
+		case "MensDoubles":
+			shuffleParticipants(data.males)
+			for i := 0; i+1 < len(data.males); i += 2 {
+				teams = append(teams, team(pool, data.males[i], data.males[i+1]))
+			}
+		case "MixedDoubles":
+			shuffleParticipants(data.males)
+			shuffleParticipants(data.females)
+			for i := 0; i < len(data.males) && i < len(data.females); i++ {
+				teams = append(teams, team(pool, data.males[i], data.females[i]))
+			}
+		}
+	}
+	return teams
+}
+
+// AutoPairTeams randomly pairs the free participants of every pool into teams of the category
+// and stores them. Withdrawn participants are left out.
+func (s *Tournament) AutoPairTeams(ctx context.Context, category string) ([]models.Team, error) {
+	participants, err := s.Store.Participants.List(ctx, repository.ParticipantFilter{Active: true})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch participants")
+	}
+	existing, err := s.Store.Teams.List(ctx, repository.TeamFilter{Category: category})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch teams")
+	}
+
+	teams := PairTeams(participants, existing, category, s.Shuffle)
+	if len(teams) == 0 {
+		return nil, nil
+	}
+	if err := s.Store.Teams.CreateMany(ctx, teams); err != nil {
+		return nil, fmt.Errorf("Failed to create teams: %w", err)
+	}
+	return teams, nil
+}
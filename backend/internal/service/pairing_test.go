@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/service"
+)
+
+func noShuffle(n int, swap func(i, j int)) {}
+
+func TestPairTeams(t *testing.T) {
+	p := func(name, pool, gender string) models.Participant {
+		return models.Participant{ID: uuid.New(), Name: name, Pool: pool, Gender: gender}
+	}
+	anh, binh, chi, dung := p("Anh", "Lab", "male"), p("Binh", "Lab", "Nam"), p("Chi", "Lab", "female"), p("Dung", "Lab", "M")
+	hoa, khoa, lan := p("Hoa", "Mesoneer", "nữ"), p("Khoa", "Mesoneer", "male"), p("Lan", "Lab", "")
+
+	tests := []struct {
+		name         string
+		participants []models.Participant
+		existing     []models.Team
+		category     string
+		want         []string // team names
+	}{
+		{
+			name:         "men's doubles pairs men within a pool",
+			participants: []models.Participant{anh, binh, chi, dung, khoa},
+			category:     "MensDoubles",
+			want:         []string{"Anh & Binh"},
+		},
+		{
+			name:         "men's doubles pairs an even number of men",
+			participants: []models.Participant{anh, binh, dung},
+			category:     "MensDoubles",
+			want:         []string{"Anh & Binh"},
+		},
+		{
+			name:         "mixed doubles pairs a man and a woman within a pool",
+			participants: []models.Participant{anh, binh, chi, hoa, khoa},
+			category:     "MixedDoubles",
+			want:         []string{"Anh & Chi", "Khoa & Hoa"},
+		},
+		{
+			name:         "players already in a team of the category are skipped",
+			participants: []models.Participant{anh, binh, chi, dung},
+			existing:     []models.Team{{Player1ID: anh.ID, Player2ID: chi.ID, Category: "MixedDoubles"}},
+			category:     "MensDoubles",
+			want:         []string{"Binh & Dung"},
+		},
+		{
+			name:         "participants without a gender are not paired",
+			participants: []models.Participant{lan, chi},
+			category:     "MixedDoubles",
+			want:         nil,
+		},
+		{
+			name:         "other categories produce no teams",
+			participants: []models.Participant{anh, binh},
+			category:     "WomensDoubles",
+			want:         nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams := service.PairTeams(tt.participants, tt.existing, tt.category, noShuffle)
+			var got []string
+			for _, team := range teams {
+				got = append(got, team.Name)
+				if team.Category != tt.category {
+					t.Errorf("%s has category %q, want %q", team.Name, team.Category, tt.category)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("teams = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("teams = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestAutoPairTeamsSkipsWithdrawn(t *testing.T) {
+	svc, store := newService(t)
+	ctx := context.Background()
+	for _, p := range []models.Participant{
+		{Name: "Anh", Pool: "Lab", Gender: "male"},
+		{Name: "Binh", Pool: "Lab", Gender: "male", Status: models.ParticipantWithdrawn},
+		{Name: "Dung", Pool: "Lab", Gender: "male"},
+	} {
+		p := p
+		if err := store.Participants.Create(ctx, &p); err != nil {
+			t.Fatalf("create participant: %v", err)
+		}
+	}
+
+	teams, err := svc.AutoPairTeams(ctx, "MensDoubles")
+	if err != nil {
+		t.Fatalf("AutoPairTeams: %v", err)
+	}
+	if len(teams) != 1 || teams[0].Name != "Anh & Dung" {
+		t.Errorf("teams = %+v, want only Anh & Dung", teams)
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+)
+
+// KnockoutGroupName is the name of the knockout stage group of a category.
+func KnockoutGroupName(category string) string {
+	if category == "" {
+		return "KNOCKOUT"
+	}
+	return "KNOCKOUT-" + category
+}
+
+// setSlot puts teamID into the "team_a_id" or "team_b_id" slot of m.
+func setSlot(m *models.Match, col string, teamID uuid.UUID) {
+	if col == "team_a_id" {
+		m.TeamAID = teamID
+	} else {
+		m.TeamBID = teamID
+	}
+}
+
+// PropagateResult routes the winner and loser of a finished match into the
+// matches that depend on it (GSL flow inside a group, cross-over into the knockout stage).
+// Run it in the same transaction as the result, so a failed route rolls the result back.
+func (s *Tournament) PropagateResult(ctx context.Context, match *models.Match, winnerID uuid.UUID) error {
+	loserID := match.TeamAID
+	if match.TeamAID == winnerID {
+		loserID = match.TeamBID
+	}
+
+	// Group stage promotion (Winners/Decider) runs BEFORE the NextMatchWinID check so that
+	// legacy pointers cannot hijack the cross-over route
+	switch {
+	case match.Label == "Winners": // M3 winner is Rank 1
+		log.Printf("[Auto-Propagation] Promoting Group Rank 1 (Winner %s) to Knockout", winnerID)
+		if err := s.promoteToKnockout(ctx, match.GroupID, 1, winnerID); err != nil {
+			return fmt.Errorf("promoting winner: %w", err)
+		}
+	case match.Label == "Decider": // M5 winner is Rank 2
+		log.Printf("[Auto-Promotion] Promoting Group Rank 2 (Decider Winner %s) to Knockout", winnerID)
+		if err := s.promoteToKnockout(ctx, match.GroupID, 2, winnerID); err != nil {
+			return fmt.Errorf("promoting decider: %w", err)
+		}
+	case match.NextMatchWinID != uuid.Nil:
+		log.Printf("[Auto-Promotion] Propagating WINNER %s to Match %s (Source: %s)", winnerID, match.NextMatchWinID, match.Label)
+		if err := s.propagateToMatch(ctx, match.NextMatchWinID, winnerID, match.Label); err != nil {
+			return fmt.Errorf("propagating winner: %w", err)
+		}
+	}
+
+	if match.NextMatchLoseID != uuid.Nil && loserID != uuid.Nil {
+		log.Printf("[Auto-Promotion] Propagating LOSER %s to Match %s (Source: %s)", loserID, match.NextMatchLoseID, match.Label)
+		if err := s.propagateToMatch(ctx, match.NextMatchLoseID, loserID, match.Label); err != nil {
+			return fmt.Errorf("propagating loser: %w", err)
+		}
+	} else if match.Label == "Losers" || match.Label == "Decider" {
+		log.Printf("[Auto-Promotion] Team %s is ELIMINATED from tournament (Lost in %s)", loserID, match.Label)
+	}
+	return nil
+}
+
+// routeSlot returns the slot of a target match that a team coming from sourceLabel takes,
+// or "" when no rule applies:
+//
+//	Winners/Losers (M3/M4): from M1 -> team A, from M2 -> team B
+//	Decider (M5):           loser of Winners -> team A, winner of Losers -> team B
+//	Final/Bronze:           from SF1 -> team A, from SF2 -> team B
+func routeSlot(targetLabel, sourceLabel string) string {
+	switch targetLabel {
+	case "Winners", "Losers":
+		switch sourceLabel {
+		case "M1":
+			return "team_a_id"
+		case "M2":
+			return "team_b_id"
+		}
+	case "Decider":
+		switch sourceLabel {
+		case "Winners":
+			return "team_a_id"
+		case "Losers":
+			return "team_b_id"
+		}
+	case "Final", "Bronze":
+		switch sourceLabel {
+		case "SF1":
+			return "team_a_id"
+		case "SF2":
+			return "team_b_id"
+		}
+	}
+	return ""
+}
+
+// crossOverSlot returns the semi-final slot of a group's rank 1 or 2 team:
+//
+//	Rank 1 Mesoneer vs Rank 2 Lab      -> SF1
+//	Rank 1 Lab      vs Rank 2 Mesoneer -> SF2
+func crossOverSlot(pool string, rank int) (label, col string) {
+	switch {
+	case pool == "Mesoneer" && rank == 1:
+		return "SF1", "team_a_id"
+	case pool == "Mesoneer":
+		return "SF2", "team_b_id"
+	case rank == 1:
+		return "SF2", "team_a_id"
+	default:
+		return "SF1", "team_b_id"
+	}
+}
+
+func (s *Tournament) propagateToMatch(ctx context.Context, targetID, teamID uuid.UUID, sourceLabel string) error {
+	target, err := s.Store.Matches.Get(ctx, targetID)
+	if err != nil {
+		return err
+	}
+
+	col := routeSlot(target.Label, sourceLabel)
+	switch {
+	case target.Label == "Decider" && col == "team_a_id":
+		log.Printf("[Auto-Promotion] Routing Loser from M3 (Winners) to Decider Match Team A: %s", teamID)
+	case target.Label == "Decider" && col == "team_b_id":
+		log.Printf("[Auto-Promotion] Routing Winner from M4 (Losers) to Decider Match Team B: %s", teamID)
+	}
+
+	// Fall back to the first empty slot if no label-specific rule matched
+	if col == "" {
+		log.Printf("[Auto-Promotion] Fallback routing activated for target %s from source %s with team %s", target.Label, sourceLabel, teamID)
+		if target.TeamAID == uuid.Nil {
+			col = "team_a_id"
+		} else if target.TeamBID == uuid.Nil {
+			col = "team_b_id"
+		}
+	}
+	if col == "" {
+		return nil
+	}
+
+	log.Printf("Promoting Team %s to Match %s", teamID, targetID) // Per ADMIN_FIX.md tracking requirement
+	log.Printf("[Auto-Promotion] SUCCESS: Pushed Player %s to Match ID %s (Column: %s)", teamID, target.ID, col)
+	setSlot(target, col, teamID)
+	return s.Store.Matches.Update(ctx, target, col)
+}
+
+// promoteToKnockout seats a group's rank 1 or 2 team in its cross-over semi-final,
+// generating the knockout stage first if needed.
+func (s *Tournament) promoteToKnockout(ctx context.Context, groupID uuid.UUID, rank int, teamID uuid.UUID) error {
+	group, err := s.Store.Groups.Get(ctx, groupID)
+	if err != nil {
+		log.Printf("PROMOTION ERROR: Source Group %v not found: %v", groupID, err)
+		return err
+	}
+
+	ko, err := s.Store.Groups.Find(ctx, repository.GroupFilter{
+		TournamentID: group.TournamentID,
+		Name:         KnockoutGroupName(group.Category),
+		Category:     group.Category,
+	})
+	if err != nil {
+		log.Printf("PROMOTION NOTICE: Knockout Stage '%s' not found. Attempting Auto-Generation...", KnockoutGroupName(group.Category))
+		if ko, err = s.EnsureKnockoutStage(ctx, group.TournamentID, group.Category); err != nil {
+			// A category with a single group has no knockout stage to promote into
+			var verr ValidationError
+			if errors.As(err, &verr) {
+				log.Printf("PROMOTION NOTICE: %v", err)
+				return nil
+			}
+			log.Printf("PROMOTION ERROR: Failed to auto-generate Knockout Stage: %v", err)
+			return err
+		}
+		log.Printf("PROMOTION SUCCESS: Auto-Generated Knockout Stage (ID: %s)", ko.ID)
+	}
+
+	targetLabel, targetCol := crossOverSlot(group.Pool, rank)
+
+	for _, m := range ko.Matches {
+		if m.Label != targetLabel {
+			continue
+		}
+		setSlot(m, targetCol, teamID)
+		if err := s.Store.Matches.Update(ctx, m, targetCol); err != nil {
+			log.Printf("PROMOTION ERROR: DB Update failed: %v", err)
+			return err
+		}
+		log.Printf("PROMOTION SUCCESS: Updated %s with Team %s", targetLabel, teamID)
+		return nil
+	}
+
+	log.Printf("PROMOTION ERROR: Target Match %s not found in DB for progression", targetLabel)
+	return nil
+}
+
+// EnsureKnockoutStage returns the knockout stage of a category, creating the group with its
+// semi-finals, Final and Bronze match if it does not exist yet. Teams are filled in by
+// promotion as the groups finish.
+func (s *Tournament) EnsureKnockoutStage(ctx context.Context, tournamentID uuid.UUID, category string) (*models.Group, error) {
+	name := KnockoutGroupName(category)
+	existing, err := s.Store.Groups.Find(ctx, repository.GroupFilter{TournamentID: tournamentID, Name: name, Category: category})
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	groups, err := s.Store.Groups.List(ctx, repository.GroupFilter{TournamentID: tournamentID, Category: category})
+	if err != nil || len(groups) < 2 {
+		return nil, ValidationError("Need at least 2 groups to generate knockout")
+	}
+
+	ko := &models.Group{TournamentID: tournamentID, Name: name, Category: category}
+	if err := s.Store.Groups.Create(ctx, ko); err != nil {
+		return nil, fmt.Errorf("Failed to create knockout group: %v", err)
+	}
+
+	// Final and Bronze first so the semi-finals can point at them
+	final := &models.Match{GroupID: ko.ID, Label: "Final"}
+	bronze := &models.Match{GroupID: ko.ID, Label: "Bronze"}
+	sf1 := &models.Match{GroupID: ko.ID, Label: "SF1"}
+	sf2 := &models.Match{GroupID: ko.ID, Label: "SF2"}
+	for _, m := range []*models.Match{final, bronze, sf1, sf2} {
+		if m == sf1 || m == sf2 {
+			m.NextMatchWinID, m.NextMatchLoseID = final.ID, bronze.ID
+		}
+		if err := s.Store.Matches.Create(ctx, m); err != nil {
+			return nil, fmt.Errorf("Failed to create knockout match %s: %v", m.Label, err)
+		}
+	}
+
+	if ko, err = s.Store.Groups.Get(ctx, ko.ID); err != nil {
+		return nil, fmt.Errorf("Failed to reload knockout group: %v", err)
+	}
+	return ko, nil
+}
@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/repository"
+	"badminton_tournament/backend/internal/service"
+)
+
+func TestOpeningMatchRouting(t *testing.T) {
+	tests := []struct {
+		name   string
+		m1, m2 string // winning side
+	}{
+		{"team A wins both", "A", "A"},
+		{"team B wins both", "B", "B"},
+		{"split A then B", "A", "B"},
+		{"split B then A", "B", "A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newService(t)
+			teams := createTeams(t, store, "Lab", "MensDoubles", 4)
+			group := createGroup(t, svc, uuid.New(), "Lab 1", "Lab", "MensDoubles", teams)
+
+			w1, l1 := play(t, svc, store, group, "M1", tt.m1)
+			w2, l2 := play(t, svc, store, group, "M2", tt.m2)
+
+			// M1 always feeds team A and M2 team B, whichever finishes first
+			assertTeams(t, matchByLabel(t, store, group, "Winners"), w1, w2)
+			assertTeams(t, matchByLabel(t, store, group, "Losers"), l1, l2)
+			assertTeams(t, matchByLabel(t, store, group, "Decider"), uuid.Nil, uuid.Nil)
+		})
+	}
+}
+
+func TestOpeningMatchRoutingOutOfOrder(t *testing.T) {
+	svc, store := newService(t)
+	teams := createTeams(t, store, "Lab", "MensDoubles", 4)
+	group := createGroup(t, svc, uuid.New(), "Lab 1", "Lab", "MensDoubles", teams)
+
+	w2, l2 := play(t, svc, store, group, "M2", "A")
+	assertTeams(t, matchByLabel(t, store, group, "Winners"), uuid.Nil, w2)
+	assertTeams(t, matchByLabel(t, store, group, "Losers"), uuid.Nil, l2)
+
+	w1, l1 := play(t, svc, store, group, "M1", "B")
+	assertTeams(t, matchByLabel(t, store, group, "Winners"), w1, w2)
+	assertTeams(t, matchByLabel(t, store, group, "Losers"), l1, l2)
+}
+
+func TestDeciderRouting(t *testing.T) {
+	tests := []struct {
+		name            string
+		winners, losers string // winning side
+	}{
+		{"team A wins both", "A", "A"},
+		{"team B wins both", "B", "B"},
+		{"split A then B", "A", "B"},
+		{"split B then A", "B", "A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newService(t)
+			teams := createTeams(t, store, "Lab", "MensDoubles", 4)
+			group := createGroup(t, svc, uuid.New(), "Lab 1", "Lab", "MensDoubles", teams)
+			play(t, svc, store, group, "M1", "A")
+			play(t, svc, store, group, "M2", "A")
+
+			_, winnersLoser := play(t, svc, store, group, "Winners", tt.winners)
+			losersWinner, _ := play(t, svc, store, group, "Losers", tt.losers)
+
+			assertTeams(t, matchByLabel(t, store, group, "Decider"), winnersLoser, losersWinner)
+		})
+	}
+}
+
+func TestCrossOverSeeding(t *testing.T) {
+	tests := []struct {
+		name    string
+		winners string // winning side of every Winners match
+		decider string // winning side of every Decider
+	}{
+		{"favourites", "A", "A"},
+		{"upsets", "B", "B"},
+		{"mixed", "A", "B"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newService(t)
+			tournamentID := uuid.New()
+			rank1 := make(map[string]uuid.UUID)
+			rank2 := make(map[string]uuid.UUID)
+			groups := make(map[string]uuid.UUID)
+			for _, pool := range []string{"Mesoneer", "Lab"} {
+				teams := createTeams(t, store, pool, "MensDoubles", 4)
+				groups[pool] = createGroup(t, svc, tournamentID, pool+" 1", pool, "MensDoubles", teams)
+			}
+			for _, pool := range []string{"Mesoneer", "Lab"} {
+				group := groups[pool]
+				play(t, svc, store, group, "M1", "A")
+				play(t, svc, store, group, "M2", "A")
+				rank1[pool], _ = play(t, svc, store, group, "Winners", tt.winners)
+				play(t, svc, store, group, "Losers", "A")
+				rank2[pool], _ = play(t, svc, store, group, "Decider", tt.decider)
+			}
+
+			ko, err := store.Groups.Find(context.Background(), repository.GroupFilter{
+				TournamentID: tournamentID,
+				Name:         service.KnockoutGroupName("MensDoubles"),
+				Category:     "MensDoubles",
+			})
+			if err != nil {
+				t.Fatalf("knockout stage was not generated: %v", err)
+			}
+
+			// Rank 1 of each pool meets rank 2 of the other
+			assertTeams(t, matchByLabel(t, store, ko.ID, "SF1"), rank1["Mesoneer"], rank2["Lab"])
+			assertTeams(t, matchByLabel(t, store, ko.ID, "SF2"), rank1["Lab"], rank2["Mesoneer"])
+
+			sf1Winner, sf1Loser := play(t, svc, store, ko.ID, "SF1", "B")
+			sf2Winner, sf2Loser := play(t, svc, store, ko.ID, "SF2", "A")
+			assertTeams(t, matchByLabel(t, store, ko.ID, "Final"), sf1Winner, sf2Winner)
+			assertTeams(t, matchByLabel(t, store, ko.ID, "Bronze"), sf1Loser, sf2Loser)
+		})
+	}
+}
+
+func TestPromotionWithoutKnockoutStage(t *testing.T) {
+	svc, store := newService(t)
+	tournamentID := uuid.New()
+	teams := createTeams(t, store, "Lab", "MensDoubles", 4)
+	group := createGroup(t, svc, tournamentID, "Lab 1", "Lab", "MensDoubles", teams)
+	play(t, svc, store, group, "M1", "A")
+	play(t, svc, store, group, "M2", "A")
+
+	// A lone group has no knockout stage to promote into; its result still counts
+	play(t, svc, store, group, "Winners", "A")
+
+	_, err := store.Groups.Find(context.Background(), repository.GroupFilter{
+		TournamentID: tournamentID,
+		Name:         service.KnockoutGroupName("MensDoubles"),
+		Category:     "MensDoubles",
+	})
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("knockout stage lookup = %v, want ErrNotFound", err)
+	}
+}
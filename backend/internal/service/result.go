@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+)
+
+// Result is a match result as entered in the score modal.
+type Result struct {
+	WinnerID   uuid.UUID
+	Score      string
+	SetsDetail string
+	VideoURL   string
+}
+
+// RecordResult stores a match result and, once it names a winner, routes the winner and
+// loser into the matches that depend on it. It returns the match before and after the change.
+func (s *Tournament) RecordResult(ctx context.Context, matchID uuid.UUID, r Result) (before, after *models.Match, err error) {
+	match, err := s.Store.Matches.Get(ctx, matchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	prev := *match
+
+	match.WinnerID = r.WinnerID
+	match.Score = r.Score
+	match.SetsDetail = r.SetsDetail
+	match.VideoURL = r.VideoURL
+	if err := s.Store.Matches.Update(ctx, match, "winner_id", "score", "sets_detail", "video_url"); err != nil {
+		return nil, nil, err
+	}
+
+	if r.WinnerID != uuid.Nil {
+		if err := s.PropagateResult(ctx, match, r.WinnerID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &prev, match, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Badminton rally scoring (BWF Laws of Badminton, Law 7):
+// a game is won by the first side to 21 points with a 2 point lead, capped at 30.
+const (
+	GamePoints    = 21
+	GameCapPoints = 30
+)
+
+// BestOf returns the number of games for a match: GSL group matches are played
+// as a single game, knockout matches are best of three.
+func BestOf(label string) int {
+	switch label {
+	case "SF1", "SF2", "Final", "Bronze":
+		return 3
+	}
+	return 1
+}
+
+// GameWinner returns "A" or "B" once a game score is decided, or "" while it is in progress.
+func GameWinner(a, b int) string {
+	if a >= GameCapPoints || (a >= GamePoints && a-b >= 2) {
+		return "A"
+	}
+	if b >= GameCapPoints || (b >= GamePoints && b-a >= 2) {
+		return "B"
+	}
+	return ""
+}
+
+// Game is the score of one game, team A first.
+type Game struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+// ParseGames reads a SetsDetail string such as "21-19, 15-21, 21-17". An empty string, as
+// stored for walkovers, has no games.
+func ParseGames(setsDetail string) ([]Game, error) {
+	if strings.TrimSpace(setsDetail) == "" {
+		return nil, nil
+	}
+	var games []Game
+	for _, part := range strings.Split(setsDetail, ",") {
+		var g Game
+		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%d-%d", &g.A, &g.B); err != nil {
+			return nil, fmt.Errorf("invalid game score %q", strings.TrimSpace(part))
+		}
+		games = append(games, g)
+	}
+	return games, nil
+}
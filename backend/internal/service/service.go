@@ -0,0 +1,27 @@
+// Package service holds the tournament rules: GSL group creation, result propagation,
+// knockout generation and team pairing. It only talks to storage through the repository
+// interfaces, so the same logic runs against Postgres or the in-memory store.
+package service
+
+import (
+	"math/rand"
+
+	"badminton_tournament/backend/internal/repository"
+)
+
+// ValidationError is returned when a request breaks a tournament rule; handlers report it
+// as a 400 with the message as-is.
+type ValidationError string
+
+func (e ValidationError) Error() string { return string(e) }
+
+type Tournament struct {
+	Store *repository.Store
+	// Shuffle randomises seeding and pairing; tests and simulations can replace it to get
+	// a deterministic draw.
+	Shuffle func(n int, swap func(i, j int))
+}
+
+func New(store *repository.Store) *Tournament {
+	return &Tournament{Store: store, Shuffle: rand.Shuffle}
+}
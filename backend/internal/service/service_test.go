@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"badminton_tournament/backend/internal/models"
+	"badminton_tournament/backend/internal/repository"
+	"badminton_tournament/backend/internal/repository/memory"
+	"badminton_tournament/backend/internal/service"
+)
+
+// newService returns a service on an empty in-memory store whose draws keep the given order.
+func newService(t *testing.T) (*service.Tournament, *repository.Store) {
+	t.Helper()
+	store := memory.NewStore()
+	svc := service.New(store)
+	svc.Shuffle = func(n int, swap func(i, j int)) {}
+	return svc, store
+}
+
+func createTeams(t *testing.T, store *repository.Store, pool, category string, n int) []uuid.UUID {
+	t.Helper()
+	teams := make([]models.Team, n)
+	for i := range teams {
+		teams[i] = models.Team{
+			Name:      fmt.Sprintf("%s %d", pool, i+1),
+			Pool:      pool,
+			Category:  category,
+			Player1ID: uuid.New(),
+			Player2ID: uuid.New(),
+		}
+	}
+	if err := store.Teams.CreateMany(context.Background(), teams); err != nil {
+		t.Fatalf("create teams: %v", err)
+	}
+	ids := make([]uuid.UUID, n)
+	for i, team := range teams {
+		ids[i] = team.ID
+	}
+	return ids
+}
+
+// createGroup creates a GSL group seeded in the order of teamIDs: M1 is teams 0 v 1, M2 is 2 v 3.
+func createGroup(t *testing.T, svc *service.Tournament, tournamentID uuid.UUID, name, pool, category string, teamIDs []uuid.UUID) uuid.UUID {
+	t.Helper()
+	group, _, err := svc.CreateGroup(context.Background(), service.CreateGroupInput{
+		Name:         name,
+		Pool:         pool,
+		TournamentID: tournamentID,
+		TeamIDs:      teamIDs,
+		Category:     category,
+	})
+	if err != nil {
+		t.Fatalf("create group %s: %v", name, err)
+	}
+	return group.ID
+}
+
+func matchByLabel(t *testing.T, store *repository.Store, groupID uuid.UUID, label string) *models.Match {
+	t.Helper()
+	group, err := store.Groups.Get(context.Background(), groupID)
+	if err != nil {
+		t.Fatalf("get group: %v", err)
+	}
+	for _, m := range group.Matches {
+		if m.Label == label {
+			return m
+		}
+	}
+	t.Fatalf("group %s has no %s match", group.Name, label)
+	return nil
+}
+
+// play records a win for side "A" or "B" of a match and returns its winner and loser.
+func play(t *testing.T, svc *service.Tournament, store *repository.Store, groupID uuid.UUID, label, side string) (winner, loser uuid.UUID) {
+	t.Helper()
+	m := matchByLabel(t, store, groupID, label)
+	if m.TeamAID == uuid.Nil || m.TeamBID == uuid.Nil {
+		t.Fatalf("%s is not ready: %s v %s", label, m.TeamAID, m.TeamBID)
+	}
+	winner, loser = m.TeamAID, m.TeamBID
+	if side == "B" {
+		winner, loser = loser, winner
+	}
+	if _, _, err := svc.RecordResult(context.Background(), m.ID, service.Result{WinnerID: winner, Score: "21-15"}); err != nil {
+		t.Fatalf("record %s: %v", label, err)
+	}
+	return winner, loser
+}
+
+func assertTeams(t *testing.T, m *models.Match, wantA, wantB uuid.UUID) {
+	t.Helper()
+	if m.TeamAID != wantA || m.TeamBID != wantB {
+		t.Errorf("%s = %s v %s, want %s v %s", m.Label, m.TeamAID, m.TeamBID, wantA, wantB)
+	}
+}